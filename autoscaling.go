@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2ac "k8s.io/client-go/applyconfigurations/autoscaling/v2"
+	"k8s.io/utils/ptr"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/convert"
+)
+
+// autoscalers returns the kind of the HorizontalPodAutoscalers controlled by
+// Foos.
+func (c *Controller) autoscalers() childKind[autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2ac.HorizontalPodAutoscalerApplyConfiguration] {
+	return childKind[autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2ac.HorizontalPodAutoscalerApplyConfiguration]{
+		c:    c,
+		kind: "HorizontalPodAutoscaler",
+		lister: func(namespace string) childLister[*autoscalingv2.HorizontalPodAutoscaler] {
+			return c.hpasLister.HorizontalPodAutoscalers(namespace)
+		},
+		client: func(namespace string) childClient[*autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2ac.HorizontalPodAutoscalerApplyConfiguration] {
+			return c.kubeclientset.AutoscalingV2().HorizontalPodAutoscalers(namespace)
+		},
+	}
+}
+
+// syncAutoscaler makes the HorizontalPodAutoscaler of foo match
+// spec.autoscaling and returns it, or deletes it and returns nil when the Foo
+// asks for none. Like the Service, a HorizontalPodAutoscaler of the same name
+// that the Foo does not control is reported as a conflict.
+func (c *Controller) syncAutoscaler(ctx context.Context, foo *samplev1alpha1.Foo) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	if foo.Spec.Autoscaling == nil {
+		return nil, c.autoscalers().delete(ctx, foo, foo.Name)
+	}
+	applyConfig, err := newAutoscalerApplyConfiguration(foo)
+	if err != nil {
+		return nil, err
+	}
+	return c.autoscalers().sync(ctx, foo, foo.Name, applyConfig)
+}
+
+// newAutoscalerApplyConfiguration returns the HorizontalPodAutoscaler
+// requested by the spec.autoscaling of foo. It is named after the Foo and
+// scales the Deployment named by it.
+func newAutoscalerApplyConfiguration(foo *samplev1alpha1.Foo) (*autoscalingv2ac.HorizontalPodAutoscalerApplyConfiguration, error) {
+	autoscaling := foo.Spec.Autoscaling
+	spec := autoscalingv2ac.HorizontalPodAutoscalerSpec().
+		WithScaleTargetRef(autoscalingv2ac.CrossVersionObjectReference().
+			WithAPIVersion(appsv1.SchemeGroupVersion.String()).
+			WithKind("Deployment").
+			WithName(foo.Spec.DeploymentName)).
+		WithMaxReplicas(autoscaling.MaxReplicas)
+	if autoscaling.MinReplicas != nil {
+		spec.WithMinReplicas(*autoscaling.MinReplicas)
+	}
+	if len(autoscaling.Metrics) > 0 {
+		if err := convert.ViaJSON(autoscaling.Metrics, &spec.Metrics); err != nil {
+			return nil, err
+		}
+	}
+
+	return autoscalingv2ac.HorizontalPodAutoscaler(foo.Name, foo.Namespace).
+		WithAnnotations(map[string]string{
+			DesiredStateHashAnnotation: computeHash(autoscaling),
+		}).
+		WithOwnerReferences(fooOwnerReference(foo)).
+		WithSpec(spec), nil
+}
+
+// keepAutoscaledReplicas leaves the replicas of an autoscaled Foo's
+// Deployment to its HorizontalPodAutoscaler: desired keeps the replica count
+// of the live Deployment instead of spec.replicas. Omitting the field instead
+// would have the API server reset it to its default of 1 while the
+// controller still owns it.
+func keepAutoscaledReplicas(foo *samplev1alpha1.Foo, desired, live *appsv1.Deployment) {
+	if foo.Spec.Autoscaling == nil || live.Spec.Replicas == nil {
+		return
+	}
+	desired.Spec.Replicas = ptr.To(*live.Spec.Replicas)
+}
+
+// autoscalerStatus reports hpa in the status of a Foo.
+func autoscalerStatus(hpa *autoscalingv2.HorizontalPodAutoscaler) *samplev1alpha1.FooAutoscalingStatus {
+	if hpa == nil {
+		return nil
+	}
+	return &samplev1alpha1.FooAutoscalingStatus{
+		Name:            hpa.Name,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/convert"
+)
+
+// newAutoscaledFoo returns a Foo asking for a HorizontalPodAutoscaler that
+// scales it between 2 and 10 replicas on CPU utilization.
+func newAutoscaledFoo() *samplecontroller.Foo {
+	foo := newFoo("test", int32Ptr(2))
+	foo.Spec.Autoscaling = &samplecontroller.FooAutoscaling{
+		MinReplicas: ptr.To[int32](2),
+		MaxReplicas: 10,
+		Metrics: []autoscalingv2.MetricSpec{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To[int32](60),
+				},
+			},
+		}},
+	}
+	return foo
+}
+
+// newAutoscaler returns the HorizontalPodAutoscaler of foo as the API server
+// stores it, after it scaled the Deployment to replicas.
+func newAutoscaler(t *testing.T, foo *samplecontroller.Foo, replicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+	applyConfig, err := newAutoscalerApplyConfiguration(foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := convert.ViaJSON(applyConfig, hpa); err != nil {
+		t.Fatal(err)
+	}
+	hpa.Status = autoscalingv2.HorizontalPodAutoscalerStatus{
+		CurrentReplicas: replicas,
+		DesiredReplicas: replicas,
+	}
+	return hpa
+}
+
+func TestAutoscaledReplicasNotEnforced(t *testing.T) {
+	f := newFixture(t)
+	foo := newAutoscaledFoo()
+	_, ctx := ktesting.NewTestContext(t)
+
+	// The autoscaler scaled the Deployment beyond spec.replicas.
+	d := newDeployment(foo, "")
+	d.Spec.Replicas = int32Ptr(5)
+	hpa := newAutoscaler(t, foo, 5)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.hpaLister = append(f.hpaLister, hpa)
+	f.kubeobjects = append(f.kubeobjects, d, hpa)
+
+	scaled := foo.DeepCopy()
+	scaled.Spec.Replicas = int32Ptr(5)
+	status := autoscalerChild.withStatus(rollingOutStatus(scaled), hpa)
+	if status.Autoscaling.CurrentReplicas != 5 || status.Autoscaling.DesiredReplicas != 5 {
+		t.Fatalf("expected the replicas of the autoscaler to be reported, got %+v", status.Autoscaling)
+	}
+	f.expectApplyFooStatusAction(withStatus(foo, status))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestEnablingAutoscalingKeepsReplicas(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(5))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	// Autoscaling is enabled and spec.replicas lowered at the same time.
+	autoscaled := newAutoscaledFoo()
+	foo.Spec.Replicas = autoscaled.Spec.Replicas
+	foo.Spec.Autoscaling = autoscaled.Spec.Autoscaling
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	expDeployment := newDeployment(foo, "")
+	expDeployment.Spec.Replicas = int32Ptr(5)
+	f.expectApplyDeploymentAction(expDeployment)
+	f.expectApplyChildAction(autoscalerChild, foo)
+	scaled := foo.DeepCopy()
+	scaled.Spec.Replicas = int32Ptr(5)
+	f.expectApplyFooStatusAction(withStatus(foo, autoscalerChild.withStatus(rollingOutStatus(scaled), &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: foo.Name},
+	})))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestDisablingAutoscalingEnforcesReplicas(t *testing.T) {
+	f := newFixture(t)
+	foo := newAutoscaledFoo()
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.Spec.Replicas = int32Ptr(5)
+	hpa := newAutoscaler(t, foo, 5)
+	foo.Spec.Autoscaling = nil
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.hpaLister = append(f.hpaLister, hpa)
+	f.kubeobjects = append(f.kubeobjects, d, hpa)
+
+	f.expectApplyDeploymentAction(newDeployment(foo, ""))
+	f.expectDeleteChildAction(autoscalerChild, hpa)
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestAutoscalerMetricsRoundTrip(t *testing.T) {
+	f := newFixture(t)
+	foo := newAutoscaledFoo()
+	target := autoscalingv2.MetricTarget{
+		Type:         autoscalingv2.AverageValueMetricType,
+		AverageValue: ptr.To(resource.MustParse("100m")),
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"queue": "work"}}
+	foo.Spec.Autoscaling.Metrics = append(foo.Spec.Autoscaling.Metrics,
+		autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ContainerResourceMetricSourceType,
+			ContainerResource: &autoscalingv2.ContainerResourceMetricSource{
+				Name:      corev1.ResourceMemory,
+				Container: "nginx",
+				Target:    autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To[int32](80)},
+			},
+		},
+		autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+				Target: target,
+			},
+		},
+		autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ObjectMetricSourceType,
+			Object: &autoscalingv2.ObjectMetricSource{
+				DescribedObject: autoscalingv2.CrossVersionObjectReference{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "main"},
+				Metric:          autoscalingv2.MetricIdentifier{Name: "requests_per_second", Selector: selector},
+				Target:          autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: ptr.To(resource.MustParse("2k"))},
+			},
+		},
+		autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "queue_length", Selector: selector},
+				Target: target,
+			},
+		},
+	)
+	_, ctx := ktesting.NewTestContext(t)
+
+	// Every kind of metric reaches the HorizontalPodAutoscaler unchanged.
+	hpa := newAutoscaler(t, foo, 2)
+	if !equality.Semantic.DeepEqual(hpa.Spec.Metrics, foo.Spec.Autoscaling.Metrics) {
+		t.Fatalf("expected the metrics %+v, got %+v", foo.Spec.Autoscaling.Metrics, hpa.Spec.Metrics)
+	}
+
+	d := newDeployment(foo, "")
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.hpaLister = append(f.hpaLister, hpa)
+	f.kubeobjects = append(f.kubeobjects, d, hpa)
+
+	// So they are not seen as drift either.
+	f.expectApplyFooStatusAction(withStatus(foo, autoscalerChild.withStatus(rollingOutStatus(foo), hpa)))
+	f.run(ctx, getRef(foo, t))
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+const (
+	// CanaryProgressed is used as part of the Event 'reason' when a canary
+	// rollout moves to its next step
+	CanaryProgressed = "CanaryProgressed"
+	// CanaryPaused is used as part of the Event 'reason' when a canary
+	// rollout is held at a step
+	CanaryPaused = "CanaryPaused"
+	// CanaryPromoted is used as part of the Event 'reason' when the template
+	// of a canary rollout is promoted
+	CanaryPromoted = "CanaryPromoted"
+	// CanaryAborted is used as part of the Event 'reason' when a canary
+	// rollout is aborted
+	CanaryAborted = "CanaryAborted"
+
+	// MessageCanaryProgressed is the message used for Events when a canary
+	// rollout moves to its next step
+	MessageCanaryProgressed = "Canary Deployment %q moved to step %d of %d with %d replicas"
+	// MessageCanaryPaused is the message used for Events when a canary
+	// rollout is held at a step
+	MessageCanaryPaused = "Canary Deployment %q paused at step %d of %d"
+	// MessageCanaryPromoted is the message used for Events when the template
+	// of a canary rollout is promoted
+	MessageCanaryPromoted = "Promoted the template of canary Deployment %q to Deployment %q"
+	// MessageCanaryAborted is the message used for Events when a canary
+	// rollout is aborted
+	MessageCanaryAborted = "Aborted canary Deployment %q, Deployment %q keeps its previous template"
+
+	// CanaryTrackLabel tells the pods of the canary Deployment of a Foo apart
+	// from those of its Deployment. Both carry the selector labels of the
+	// Foo, so that its Service and PodDisruptionBudget select them all.
+	CanaryTrackLabel = "samplecontroller.k8s.io/track"
+	canaryTrack      = "canary"
+)
+
+// canaryDeploymentName returns the name of the canary Deployment of foo.
+func canaryDeploymentName(foo *samplev1alpha1.Foo) string {
+	return foo.Spec.DeploymentName + "-canary"
+}
+
+// progressCanary moves the canary rollout of the template of foo, identified
+// by hash, through the steps of spec.strategy.canary and records it in
+// status.canary. While the rollout runs, or once it was aborted, desired
+// keeps the last good template and, for each step, is scaled down by the
+// replicas of the canary. It returns the canary Deployment to run, or nil if
+// there is none.
+func (c *Controller) progressCanary(ctx context.Context, foo *samplev1alpha1.Foo, desired *appsv1.Deployment, hash string, rolledBack bool) (*appsv1.Deployment, error) {
+	status := foo.Status.Canary
+	rollout := foo.Status.Rollout
+	if foo.Spec.Strategy == nil || foo.Spec.Strategy.Canary == nil || rolledBack ||
+		rollout == nil || rollout.LastGoodTemplate == nil || rollout.LastGoodTemplateHash == hash {
+		// The template is rolled out as usual. A canary of another template
+		// that was still running is dropped with it.
+		if canaryRunning(status) {
+			foo.Status.Canary = nil
+		}
+		return nil, nil
+	}
+	if status != nil && status.TemplateHash == hash {
+		switch status.Phase {
+		case samplev1alpha1.FooCanaryPromoted:
+			return nil, nil
+		case samplev1alpha1.FooCanaryAborted:
+			holdStableTemplate(foo, desired, desiredReplicas(desired))
+			return nil, nil
+		}
+	} else {
+		status = &samplev1alpha1.FooCanaryStatus{
+			DeploymentName: canaryDeploymentName(foo),
+			TemplateHash:   hash,
+			Phase:          samplev1alpha1.FooCanaryProgressing,
+		}
+		foo.Status.Canary = status
+	}
+
+	if foo.Annotations[samplev1alpha1.AbortCanaryAnnotation] == "true" {
+		if err := c.removeFooAnnotation(ctx, foo, samplev1alpha1.AbortCanaryAnnotation); err != nil {
+			return nil, err
+		}
+		status.Phase = samplev1alpha1.FooCanaryAborted
+		status.PauseStartTime = nil
+		c.recorder.Eventf(foo, desired, corev1.EventTypeNormal, CanaryAborted, ActionRollout, MessageCanaryAborted, status.DeploymentName, desired.Name)
+		holdStableTemplate(foo, desired, desiredReplicas(desired))
+		return nil, nil
+	}
+	steps := foo.Spec.Strategy.Canary.Steps
+	if foo.Annotations[samplev1alpha1.PromoteCanaryAnnotation] == "true" || int(status.Step) >= len(steps) {
+		if err := c.removeFooAnnotation(ctx, foo, samplev1alpha1.PromoteCanaryAnnotation); err != nil {
+			return nil, err
+		}
+		c.promoteCanary(foo, desired)
+		return nil, nil
+	}
+
+	total := desiredReplicas(desired)
+	canary := newCanaryDeployment(foo, desired, canaryReplicas(steps[status.Step], total))
+	live, err := c.deploymentsLister.Deployments(foo.Namespace).Get(canary.Name)
+	if errors.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return nil, err
+	}
+	status.AvailableReplicas = 0
+	if live != nil && metav1.IsControlledBy(live, foo) {
+		status.AvailableReplicas = live.Status.AvailableReplicas
+	}
+
+	// The step is complete once the canary Deployment runs its replicas.
+	if live != nil && metav1.IsControlledBy(live, foo) &&
+		live.Annotations[DesiredStateHashAnnotation] == canary.Annotations[DesiredStateHashAnnotation] &&
+		deploymentRolledOut(live) && c.canaryStepPassed(foo, live, steps) {
+		status.Step++
+		status.Phase = samplev1alpha1.FooCanaryProgressing
+		status.PauseStartTime = nil
+		if int(status.Step) >= len(steps) {
+			c.promoteCanary(foo, desired)
+			return nil, nil
+		}
+		canary = newCanaryDeployment(foo, desired, canaryReplicas(steps[status.Step], total))
+		c.recorder.Eventf(foo, live, corev1.EventTypeNormal, CanaryProgressed, ActionRollout, MessageCanaryProgressed, canary.Name, status.Step+1, len(steps), *canary.Spec.Replicas)
+	}
+
+	status.Replicas = *canary.Spec.Replicas
+	holdStableTemplate(foo, desired, max(total-status.Replicas, 0))
+	return canary, nil
+}
+
+// canaryStepPassed reports whether the current step of the canary rollout of
+// foo, whose replicas are available on canary, may be left. A step with a pause holds
+// the rollout until its duration has passed, or until it is promoted if it
+// has none.
+func (c *Controller) canaryStepPassed(foo *samplev1alpha1.Foo, canary *appsv1.Deployment, steps []samplev1alpha1.FooCanaryStep) bool {
+	status := foo.Status.Canary
+	pause := steps[status.Step].Pause
+	if pause == nil {
+		return true
+	}
+	now := c.clock.Now()
+	if status.Phase != samplev1alpha1.FooCanaryPaused {
+		status.Phase = samplev1alpha1.FooCanaryPaused
+		status.PauseStartTime = ptr.To(metav1.NewTime(now))
+		c.recorder.Eventf(foo, canary, corev1.EventTypeNormal, CanaryPaused, ActionRollout, MessageCanaryPaused, status.DeploymentName, status.Step+1, len(steps))
+	}
+	if pause.Duration == nil {
+		return false
+	}
+	remaining := status.PauseStartTime.Add(pause.Duration.Duration).Sub(now)
+	if remaining > 0 {
+		c.workqueue.AddAfter(cache.MetaObjectToName(foo), remaining)
+		return false
+	}
+	return true
+}
+
+// promoteCanary marks the canary rollout of foo as promoted, which leaves
+// desired with the template of the Foo.
+func (c *Controller) promoteCanary(foo *samplev1alpha1.Foo, desired *appsv1.Deployment) {
+	status := foo.Status.Canary
+	status.Phase = samplev1alpha1.FooCanaryPromoted
+	status.PauseStartTime = nil
+	c.recorder.Eventf(foo, desired, corev1.EventTypeNormal, CanaryPromoted, ActionRollout, MessageCanaryPromoted, status.DeploymentName, desired.Name)
+}
+
+// canaryRunning reports whether status describes a canary rollout that is
+// neither promoted nor aborted.
+func canaryRunning(status *samplev1alpha1.FooCanaryStatus) bool {
+	return status != nil &&
+		(status.Phase == samplev1alpha1.FooCanaryProgressing || status.Phase == samplev1alpha1.FooCanaryPaused)
+}
+
+// canaryHoldsStableTemplate reports whether the Deployment of foo keeps the
+// last good template because the canary rollout of the template identified
+// by hash runs or was aborted.
+func canaryHoldsStableTemplate(foo *samplev1alpha1.Foo, hash string) bool {
+	status := foo.Status.Canary
+	return status != nil && status.TemplateHash == hash &&
+		(canaryRunning(status) || status.Phase == samplev1alpha1.FooCanaryAborted)
+}
+
+// holdStableTemplate sets the last good template of foo and the given
+// replicas on desired.
+func holdStableTemplate(foo *samplev1alpha1.Foo, desired *appsv1.Deployment, replicas int32) {
+	desired.Spec.Template = *foo.Status.Rollout.LastGoodTemplate.DeepCopy()
+	desired.Spec.Replicas = ptr.To(replicas)
+	desired.Annotations[DesiredStateHashAnnotation] = desiredStateHash(foo, desired.Spec)
+}
+
+// canaryReplicas returns the canary replicas of step out of total replicas,
+// at least one and at most total.
+func canaryReplicas(step samplev1alpha1.FooCanaryStep, total int32) int32 {
+	replicas, _ := intstr.GetScaledValueFromIntOrPercent(&step.Replicas, int(total), true)
+	return int32(max(min(replicas, int(total)), 1))
+}
+
+// newCanaryDeployment returns the canary Deployment of foo, running the
+// template of desired on the given number of replicas. Its pods carry the
+// CanaryTrackLabel on top of the selector labels of the Foo.
+func newCanaryDeployment(foo *samplev1alpha1.Foo, desired *appsv1.Deployment, replicas int32) *appsv1.Deployment {
+	canary := desired.DeepCopy()
+	canary.Name = canaryDeploymentName(foo)
+	canary.Spec.Replicas = ptr.To(replicas)
+	canary.Spec.Selector.MatchLabels[CanaryTrackLabel] = canaryTrack
+	canary.Spec.Template.Labels[CanaryTrackLabel] = canaryTrack
+	canary.Annotations[DesiredStateHashAnnotation] = computeHash(canary.Spec)
+	return canary
+}
+
+// syncCanaryDeployment makes the canary Deployment of foo match desired, or
+// deletes it when desired is nil. The canary of a promoted template is only
+// deleted once stable runs that template on all of its replicas. Like the
+// Service, a Deployment of the same name that the Foo does not control is
+// reported as a conflict.
+func (c *Controller) syncCanaryDeployment(ctx context.Context, foo *samplev1alpha1.Foo, desired, stable *appsv1.Deployment) error {
+	logger := klog.FromContext(ctx)
+
+	canary, err := c.deploymentsLister.Deployments(foo.Namespace).Get(canaryDeploymentName(foo))
+	if errors.IsNotFound(err) {
+		canary = nil
+	} else if err != nil {
+		return err
+	}
+
+	if desired == nil {
+		if canary == nil || !metav1.IsControlledBy(canary, foo) {
+			return nil
+		}
+		if status := foo.Status.Canary; status != nil && status.Phase == samplev1alpha1.FooCanaryPromoted && !deploymentRolledOut(stable) {
+			logger.V(4).Info("Waiting for deployment before deleting canary", "deployment", klog.KObj(stable), "canary", klog.KObj(canary))
+			return nil
+		}
+		logger.V(4).Info("Deleting canary deployment", "deployment", klog.KObj(canary))
+		err = c.kubeclientset.AppsV1().Deployments(canary.Namespace).Delete(ctx, canary.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(canary.UID)),
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if canary != nil && !metav1.IsControlledBy(canary, foo) {
+		msg := fmt.Sprintf(MessageResourceExists, canary.Name)
+		if err := c.updateFooConflictStatus(ctx, foo, canary, samplev1alpha1.FooResourceConflict, ErrResourceExists, msg); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	if canary != nil && canary.Annotations[DesiredStateHashAnnotation] == desired.Annotations[DesiredStateHashAnnotation] {
+		drifted, err := deploymentDrift(desired, canary)
+		if err != nil || len(drifted) == 0 {
+			return err
+		}
+	}
+	logger.V(4).Info("Update canary deployment resource", "deployment", klog.KObj(desired), "replicas", desired.Spec.Replicas)
+	_, err = c.applyDeployment(ctx, desired, true)
+	return err
+}
+
+// removeFooAnnotation removes the annotation key from foo, if it is set.
+func (c *Controller) removeFooAnnotation(ctx context.Context, foo *samplev1alpha1.Foo, key string) error {
+	if _, ok := foo.Annotations[key]; !ok {
+		return nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, key)
+	_, err := c.sampleclientset.SamplecontrollerV1alpha1().Foos(foo.Namespace).Patch(ctx, foo.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{FieldManager: FieldManager})
+	return err
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2/ktesting"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	samplescheme "k8s.io/sample-controller/pkg/generated/clientset/versioned/scheme"
+)
+
+// newCanaryFoo returns a Foo of four replicas rolled out through the given
+// canary steps, whose template was changed, along with its Deployment, which
+// runs the previous template on all of them.
+func newCanaryFoo(steps ...samplecontroller.FooCanaryStep) (*samplecontroller.Foo, *apps.Deployment) {
+	foo := newFoo("test", int32Ptr(4))
+	stable := newDeployment(foo, "")
+	stable.Status = availableStatus(4)
+	foo.Status.Rollout = &samplecontroller.FooRolloutStatus{
+		LastGoodRevision:     "1",
+		LastGoodTemplateHash: templateHash(stable),
+		LastGoodTemplate:     &stable.Spec.Template,
+	}
+	foo.Spec.Strategy = &samplecontroller.FooStrategy{
+		Canary: &samplecontroller.FooCanaryStrategy{Steps: steps},
+	}
+	foo.Spec.Template.Spec.Containers = []corev1.Container{{Name: "nginx", Image: "nginx:canary"}}
+	samplescheme.Scheme.Default(foo)
+	return foo, stable
+}
+
+// availableStatus is the status of a Deployment running its template on all
+// of the given replicas.
+func availableStatus(replicas int32) apps.DeploymentStatus {
+	return apps.DeploymentStatus{
+		Replicas:          replicas,
+		UpdatedReplicas:   replicas,
+		ReadyReplicas:     replicas,
+		AvailableReplicas: replicas,
+	}
+}
+
+// syncCanary syncs foo once and returns the Foo, its Deployment and its
+// canary Deployment as stored afterwards, the latter nil if there is none.
+func (f *fixture) syncCanary(ctx context.Context, foo *samplecontroller.Foo, recorder events.EventRecorder) (*samplecontroller.Foo, *apps.Deployment, *apps.Deployment) {
+	f.t.Helper()
+	c, i, k8sI := f.newController(ctx)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, f.t)); err != nil {
+		f.t.Fatalf("error syncing foo: %v", err)
+	}
+
+	updated, err := f.client.SamplecontrollerV1alpha1().Foos(foo.Namespace).Get(ctx, foo.Name, metav1.GetOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	stable, err := f.kubeclient.AppsV1().Deployments(foo.Namespace).Get(ctx, foo.Spec.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	canary, err := f.kubeclient.AppsV1().Deployments(foo.Namespace).Get(ctx, canaryDeploymentName(foo), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		canary = nil
+	} else if err != nil {
+		f.t.Fatal(err)
+	}
+	return updated, stable, canary
+}
+
+func expectImage(t *testing.T, d *apps.Deployment, image string) {
+	t.Helper()
+	if got := d.Spec.Template.Spec.Containers[0].Image; got != image {
+		t.Errorf("expected Deployment %s to run %s, got %s", d.Name, image, got)
+	}
+}
+
+func TestStartsCanary(t *testing.T) {
+	f := newFixture(t)
+	foo, stable := newCanaryFoo(samplecontroller.FooCanaryStep{Replicas: intstr.FromString("25%"), Pause: &samplecontroller.FooCanaryPause{}})
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, stable)
+	f.kubeobjects = append(f.kubeobjects, stable)
+
+	updated, stable, canary := f.syncCanary(ctx, foo, &events.FakeRecorder{})
+
+	expectImage(t, stable, "nginx:latest")
+	if *stable.Spec.Replicas != 3 {
+		t.Errorf("expected the Deployment to be scaled to 3 replicas, got %d", *stable.Spec.Replicas)
+	}
+	if canary == nil {
+		t.Fatal("expected a canary Deployment")
+	}
+	expectImage(t, canary, "nginx:canary")
+	if *canary.Spec.Replicas != 1 {
+		t.Errorf("expected the canary Deployment to run 1 replica, got %d", *canary.Spec.Replicas)
+	}
+	if canary.Spec.Selector.MatchLabels[CanaryTrackLabel] != canaryTrack || canary.Spec.Template.Labels["controller"] != foo.Name {
+		t.Errorf("expected the canary pods to carry the selector labels and the track label, got %v", canary.Spec.Template.Labels)
+	}
+	expected := &samplecontroller.FooCanaryStatus{
+		DeploymentName: canary.Name,
+		TemplateHash:   templateHash(newDeployment(foo, "")),
+		Phase:          samplecontroller.FooCanaryProgressing,
+		Replicas:       1,
+	}
+	if status := updated.Status.Canary; status == nil || *status != *expected {
+		t.Errorf("expected canary status %+v, got %+v", expected, status)
+	}
+	if updated.Status.Rollout.LastGoodTemplateHash != foo.Status.Rollout.LastGoodTemplateHash {
+		t.Errorf("expected the last good template to be kept, got %+v", updated.Status.Rollout)
+	}
+}
+
+// newRunningCanaryFoo returns the Foo of newCanaryFoo at its first step, along
+// with its Deployment, scaled down for the step, and its canary Deployment,
+// whose replicas are available.
+func newRunningCanaryFoo(steps ...samplecontroller.FooCanaryStep) (*samplecontroller.Foo, *apps.Deployment, *apps.Deployment) {
+	foo, stable := newCanaryFoo(steps...)
+	desired := newDeployment(foo, "")
+	replicas := canaryReplicas(steps[0], 4)
+	foo.Status.Canary = &samplecontroller.FooCanaryStatus{
+		DeploymentName: canaryDeploymentName(foo),
+		TemplateHash:   templateHash(desired),
+		Phase:          samplecontroller.FooCanaryProgressing,
+		Replicas:       replicas,
+	}
+	canary := newCanaryDeployment(foo, desired, replicas)
+	canary.Status = availableStatus(replicas)
+	holdStableTemplate(foo, stable, 4-replicas)
+	stable.Status = availableStatus(4 - replicas)
+	return foo, stable, canary
+}
+
+func TestPausesCanary(t *testing.T) {
+	f := newFixture(t)
+	foo, stable, canary := newRunningCanaryFoo(samplecontroller.FooCanaryStep{Replicas: intstr.FromInt32(1), Pause: &samplecontroller.FooCanaryPause{}})
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, stable, canary)
+	f.kubeobjects = append(f.kubeobjects, stable, canary)
+
+	recorder := events.NewFakeRecorder(4)
+	updated, stable, canary := f.syncCanary(ctx, foo, recorder)
+
+	status := updated.Status.Canary
+	if status.Phase != samplecontroller.FooCanaryPaused || status.Step != 0 || status.PauseStartTime == nil || !status.PauseStartTime.Equal(&syncTime) {
+		t.Errorf("expected the canary to be paused at its first step, got %+v", status)
+	}
+	if status.AvailableReplicas != 1 {
+		t.Errorf("expected 1 available canary replica, got %d", status.AvailableReplicas)
+	}
+	expectImage(t, stable, "nginx:latest")
+	if canary == nil || *canary.Spec.Replicas != 1 {
+		t.Errorf("expected the canary Deployment to keep 1 replica, got %+v", canary)
+	}
+	expectEvent(t, recorder, CanaryPaused)
+}
+
+func TestAdvancesCanaryAfterPause(t *testing.T) {
+	f := newFixture(t)
+	foo, stable, canary := newRunningCanaryFoo(
+		samplecontroller.FooCanaryStep{Replicas: intstr.FromInt32(1), Pause: &samplecontroller.FooCanaryPause{Duration: &metav1.Duration{Duration: time.Minute}}},
+		samplecontroller.FooCanaryStep{Replicas: intstr.FromString("50%")},
+	)
+	foo.Status.Canary.Phase = samplecontroller.FooCanaryPaused
+	foo.Status.Canary.PauseStartTime = &metav1.Time{Time: syncTime.Add(-2 * time.Minute)}
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, stable, canary)
+	f.kubeobjects = append(f.kubeobjects, stable, canary)
+
+	recorder := events.NewFakeRecorder(4)
+	updated, stable, canary := f.syncCanary(ctx, foo, recorder)
+
+	status := updated.Status.Canary
+	if status.Phase != samplecontroller.FooCanaryProgressing || status.Step != 1 || status.Replicas != 2 {
+		t.Errorf("expected the canary to move to its second step, got %+v", status)
+	}
+	if *stable.Spec.Replicas != 2 || *canary.Spec.Replicas != 2 {
+		t.Errorf("expected 2 stable and 2 canary replicas, got %d and %d", *stable.Spec.Replicas, *canary.Spec.Replicas)
+	}
+	expectEvent(t, recorder, CanaryProgressed)
+}
+
+func TestPromotesCanary(t *testing.T) {
+	f := newFixture(t)
+	foo, stable, canary := newRunningCanaryFoo(samplecontroller.FooCanaryStep{Replicas: intstr.FromInt32(1), Pause: &samplecontroller.FooCanaryPause{}})
+	foo.Status.Canary.Phase = samplecontroller.FooCanaryPaused
+	foo.Annotations = map[string]string{samplecontroller.PromoteCanaryAnnotation: "true"}
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, stable, canary)
+	f.kubeobjects = append(f.kubeobjects, stable, canary)
+
+	recorder := events.NewFakeRecorder(4)
+	updated, stable, canary := f.syncCanary(ctx, foo, recorder)
+
+	if status := updated.Status.Canary; status.Phase != samplecontroller.FooCanaryPromoted {
+		t.Errorf("expected the canary to be promoted, got %+v", status)
+	}
+	if _, ok := updated.Annotations[samplecontroller.PromoteCanaryAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed", samplecontroller.PromoteCanaryAnnotation)
+	}
+	expectImage(t, stable, "nginx:canary")
+	if *stable.Spec.Replicas != 4 {
+		t.Errorf("expected the Deployment to be scaled to 4 replicas, got %d", *stable.Spec.Replicas)
+	}
+	// The canary keeps serving until the Deployment has rolled out.
+	if canary == nil {
+		t.Error("expected the canary Deployment to be kept")
+	}
+	expectEvent(t, recorder, CanaryPromoted)
+}
+
+func TestDeletesPromotedCanary(t *testing.T) {
+	f := newFixture(t)
+	foo, _, canary := newRunningCanaryFoo(samplecontroller.FooCanaryStep{Replicas: intstr.FromInt32(1)})
+	foo.Status.Canary.Phase = samplecontroller.FooCanaryPromoted
+	_, ctx := ktesting.NewTestContext(t)
+
+	stable := newDeployment(foo, "")
+	stable.Status = availableStatus(4)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, stable, canary)
+	f.kubeobjects = append(f.kubeobjects, stable, canary)
+
+	updated, _, canary := f.syncCanary(ctx, foo, &events.FakeRecorder{})
+
+	if canary != nil {
+		t.Error("expected the canary Deployment to be deleted")
+	}
+	if updated.Status.Rollout.LastGoodTemplateHash != templateHash(stable) {
+		t.Errorf("expected the promoted template to be recorded as good, got %+v", updated.Status.Rollout)
+	}
+}
+
+func TestAbortsCanary(t *testing.T) {
+	f := newFixture(t)
+	foo, stable, canary := newRunningCanaryFoo(samplecontroller.FooCanaryStep{Replicas: intstr.FromInt32(1)})
+	foo.Annotations = map[string]string{samplecontroller.AbortCanaryAnnotation: "true"}
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, stable, canary)
+	f.kubeobjects = append(f.kubeobjects, stable, canary)
+
+	recorder := events.NewFakeRecorder(4)
+	updated, stable, canary := f.syncCanary(ctx, foo, recorder)
+
+	if status := updated.Status.Canary; status.Phase != samplecontroller.FooCanaryAborted {
+		t.Errorf("expected the canary to be aborted, got %+v", status)
+	}
+	if _, ok := updated.Annotations[samplecontroller.AbortCanaryAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed", samplecontroller.AbortCanaryAnnotation)
+	}
+	expectImage(t, stable, "nginx:latest")
+	if *stable.Spec.Replicas != 4 {
+		t.Errorf("expected the Deployment to be scaled back to 4 replicas, got %d", *stable.Spec.Replicas)
+	}
+	if canary != nil {
+		t.Error("expected the canary Deployment to be deleted")
+	}
+	expectEvent(t, recorder, CanaryAborted)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// childObject is the pointer type of a kind of object controlled by a Foo,
+// like *corev1.Service for corev1.Service.
+type childObject[S any] interface {
+	*S
+	metav1.Object
+	runtime.Object
+}
+
+// childLister reads the objects of a kind in a namespace from the informer
+// cache, like a ServiceNamespaceLister.
+type childLister[T any] interface {
+	Get(name string) (T, error)
+}
+
+// childClient writes the objects of a kind in a namespace, like a
+// ServiceInterface.
+type childClient[T, AC any] interface {
+	Apply(ctx context.Context, applyConfig AC, opts metav1.ApplyOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// childKind is a kind of object controlled by a Foo, with T the pointer type
+// of its objects and AC the type of their apply configurations. It holds what
+// the children of a Foo have in common: how they are adopted, released,
+// checked for drift and deleted.
+type childKind[S any, T childObject[S], AC any] struct {
+	c *Controller
+	// kind is the kind of the objects, used in log messages.
+	kind   string
+	lister func(namespace string) childLister[T]
+	client func(namespace string) childClient[T, AC]
+}
+
+// sync makes the object named in applyConfig match it and returns it. A
+// retained object is adopted back first, and one that the Foo does not
+// control is reported as a conflict. The desired state hash annotation
+// changes with the spec of the Foo, so fields removed from the spec are
+// noticed as well as fields changed on the object.
+func (k childKind[S, T, AC]) sync(ctx context.Context, foo *samplev1alpha1.Foo, name string, applyConfig AC) (T, error) {
+	logger := klog.FromContext(ctx)
+
+	obj, err := k.lister(foo.Namespace).Get(name)
+	if errors.IsNotFound(err) {
+		obj = nil
+	} else if err != nil {
+		return nil, err
+	}
+	if obj != nil && canAdopt(foo, obj) {
+		obj, err = k.adopt(ctx, foo, obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if obj != nil && !metav1.IsControlledBy(obj, foo) {
+		msg := fmt.Sprintf(MessageResourceExists, obj.GetName())
+		if err := k.c.updateFooConflictStatus(ctx, foo, obj, samplev1alpha1.FooResourceConflict, ErrResourceExists, msg); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s", msg)
+	}
+
+	if obj != nil {
+		drifted, err := applyConfigDrift(applyConfig, obj)
+		if err != nil {
+			return nil, err
+		}
+		if len(drifted) == 0 {
+			return obj, nil
+		}
+		logger.V(4).Info("Updating object", "kind", k.kind, "object", klog.KObj(obj), "changedFields", drifted)
+	}
+	// The controller is the only writer of the fields it sets on the object,
+	// so they are taken over from anyone who changed them.
+	return k.client(foo.Namespace).Apply(ctx, applyConfig, metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
+}
+
+// owned returns the object named name that is controlled by foo, or nil if
+// there is none.
+func (k childKind[S, T, AC]) owned(foo *samplev1alpha1.Foo, name string) (T, error) {
+	obj, err := k.lister(foo.Namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(obj, foo) {
+		return nil, nil
+	}
+	return obj, nil
+}
+
+// delete deletes the object named name if foo controls it.
+func (k childKind[S, T, AC]) delete(ctx context.Context, foo *samplev1alpha1.Foo, name string) error {
+	obj, err := k.owned(foo, name)
+	if err != nil || obj == nil {
+		return err
+	}
+	klog.FromContext(ctx).V(4).Info("Deleting object", "kind", k.kind, "object", klog.KObj(obj))
+	err = k.client(obj.GetNamespace()).Delete(ctx, obj.GetName(), metav1.DeleteOptions{
+		Preconditions: metav1.NewUIDPreconditions(string(obj.GetUID())),
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// finalize applies the deletion policy of foo to obj: it is released with
+// the Orphan and Retain policies and deleted otherwise.
+func (k childKind[S, T, AC]) finalize(ctx context.Context, foo *samplev1alpha1.Foo, obj T) error {
+	logger := klog.FromContext(ctx)
+	var err error
+	switch foo.Spec.DeletionPolicy {
+	case samplev1alpha1.DeletionPolicyOrphan, samplev1alpha1.DeletionPolicyRetain:
+		logger.V(4).Info("Releasing object", "kind", k.kind, "object", klog.KObj(obj), "policy", foo.Spec.DeletionPolicy)
+		err = k.release(ctx, foo, obj)
+	default:
+		logger.V(4).Info("Deleting object", "kind", k.kind, "object", klog.KObj(obj))
+		err = k.client(obj.GetNamespace()).Delete(ctx, obj.GetName(), metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(obj.GetUID())),
+		})
+	}
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// release removes the Foo's owner reference from obj so the garbage
+// collector leaves it alone. With the Retain policy the object is also marked
+// for adoption by a future Foo of the same name.
+func (k childKind[S, T, AC]) release(ctx context.Context, foo *samplev1alpha1.Foo, obj T) error {
+	objCopy := obj.DeepCopyObject().(T)
+	objCopy.SetOwnerReferences(slices.DeleteFunc(objCopy.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.UID == foo.UID
+	}))
+	if foo.Spec.DeletionPolicy == samplev1alpha1.DeletionPolicyRetain {
+		annotations := objCopy.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[samplev1alpha1.RetainedFromAnnotation] = foo.Name
+		objCopy.SetAnnotations(annotations)
+	}
+	_, err := k.client(obj.GetNamespace()).Update(ctx, objCopy, metav1.UpdateOptions{FieldManager: FieldManager})
+	return err
+}
+
+// adopt makes foo the controller of a previously retained object.
+func (k childKind[S, T, AC]) adopt(ctx context.Context, foo *samplev1alpha1.Foo, obj T) (T, error) {
+	objCopy := obj.DeepCopyObject().(T)
+	annotations := objCopy.GetAnnotations()
+	delete(annotations, samplev1alpha1.RetainedFromAnnotation)
+	objCopy.SetAnnotations(annotations)
+	objCopy.SetOwnerReferences(append(objCopy.GetOwnerReferences(),
+		*metav1.NewControllerRef(foo, samplev1alpha1.SchemeGroupVersion.WithKind("Foo"))))
+	return k.client(obj.GetNamespace()).Update(ctx, objCopy, metav1.UpdateOptions{FieldManager: FieldManager})
+}
+
+// canAdopt reports whether obj was retained by a Foo of the same name and
+// has not been claimed by another controller since.
+func canAdopt(foo *samplev1alpha1.Foo, obj metav1.Object) bool {
+	return metav1.GetControllerOf(obj) == nil && obj.GetAnnotations()[samplev1alpha1.RetainedFromAnnotation] == foo.Name
+}
+
+// fooOwnerReference returns the apply configuration of the owner reference
+// making foo the controller of its children.
+func fooOwnerReference(foo *samplev1alpha1.Foo) *metav1ac.OwnerReferenceApplyConfiguration {
+	return ownerReference(*metav1.NewControllerRef(foo, samplev1alpha1.SchemeGroupVersion.WithKind("Foo")))
+}
+
+// ownerReference returns the apply configuration of ref.
+func ownerReference(ref metav1.OwnerReference) *metav1ac.OwnerReferenceApplyConfiguration {
+	return metav1ac.OwnerReference().
+		WithAPIVersion(ref.APIVersion).
+		WithKind(ref.Kind).
+		WithName(ref.Name).
+		WithUID(ref.UID).
+		WithController(ptr.Deref(ref.Controller, false)).
+		WithBlockOwnerDeletion(ptr.Deref(ref.BlockOwnerDeletion, false))
+}
@@ -0,0 +1,402 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	core "k8s.io/client-go/testing"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/convert"
+	samplescheme "k8s.io/sample-controller/pkg/generated/clientset/versioned/scheme"
+)
+
+// childTestObject is an object controlled by a Foo.
+type childTestObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// childTest describes a kind of the optional children of a Foo to the tests
+// all of them share.
+type childTest struct {
+	name string
+	// resource is the API resource of the children.
+	resource string
+	// request makes foo ask for a child.
+	request func(foo *samplecontroller.Foo)
+	// change changes the child foo asks for.
+	change func(foo *samplecontroller.Foo)
+	// remove makes foo ask for no child.
+	remove func(foo *samplecontroller.Foo)
+	// removeAppliesDeployment is set when removing the child from the spec
+	// of a Foo changes its Deployment as well.
+	removeAppliesDeployment bool
+	// applyConfig returns the apply configuration of the child of foo.
+	applyConfig func(foo *samplecontroller.Foo) (interface{}, error)
+	// empty returns an empty child.
+	empty func() childTestObject
+	// stored returns the child of foo as the API server stores it.
+	stored func(t *testing.T, foo *samplecontroller.Foo) childTestObject
+	// add puts obj in the lister of the fixture.
+	add func(f *fixture, obj childTestObject)
+	// withStatus reports obj in status.
+	withStatus func(status samplecontroller.FooStatus, obj childTestObject) samplecontroller.FooStatus
+}
+
+var (
+	serviceChild = childTest{
+		name:     "Service",
+		resource: "services",
+		request: func(foo *samplecontroller.Foo) {
+			foo.Spec.Service = &samplecontroller.FooService{
+				Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+			}
+		},
+		change: func(foo *samplecontroller.Foo) {
+			foo.Spec.Service.Annotations = map[string]string{"team": "a"}
+		},
+		remove: func(foo *samplecontroller.Foo) { foo.Spec.Service = nil },
+		applyConfig: func(foo *samplecontroller.Foo) (interface{}, error) {
+			return newServiceApplyConfiguration(foo), nil
+		},
+		empty: func() childTestObject { return &corev1.Service{} },
+		stored: func(t *testing.T, foo *samplecontroller.Foo) childTestObject {
+			return newService(t, foo)
+		},
+		add: func(f *fixture, obj childTestObject) {
+			f.serviceLister = append(f.serviceLister, obj.(*corev1.Service))
+		},
+		withStatus: func(status samplecontroller.FooStatus, obj childTestObject) samplecontroller.FooStatus {
+			status.Service = serviceStatus(obj.(*corev1.Service))
+			return status
+		},
+	}
+
+	disruptionBudgetChild = childTest{
+		name:     "PodDisruptionBudget",
+		resource: "poddisruptionbudgets",
+		request: func(foo *samplecontroller.Foo) {
+			foo.Spec.DisruptionBudget = &samplecontroller.FooDisruptionBudget{
+				MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+			}
+		},
+		change: func(foo *samplecontroller.Foo) {
+			foo.Spec.DisruptionBudget.MaxUnavailable = ptr.To(intstr.FromInt32(2))
+		},
+		remove: func(foo *samplecontroller.Foo) { foo.Spec.DisruptionBudget = nil },
+		applyConfig: func(foo *samplecontroller.Foo) (interface{}, error) {
+			return newDisruptionBudgetApplyConfiguration(foo), nil
+		},
+		empty: func() childTestObject { return &policyv1.PodDisruptionBudget{} },
+		stored: func(t *testing.T, foo *samplecontroller.Foo) childTestObject {
+			return newDisruptionBudget(t, foo)
+		},
+		add: func(f *fixture, obj childTestObject) {
+			f.pdbLister = append(f.pdbLister, obj.(*policyv1.PodDisruptionBudget))
+		},
+		withStatus: func(status samplecontroller.FooStatus, obj childTestObject) samplecontroller.FooStatus {
+			status.DisruptionBudget = disruptionBudgetStatus(obj.(*policyv1.PodDisruptionBudget))
+			return status
+		},
+	}
+
+	autoscalerChild = childTest{
+		name:     "HorizontalPodAutoscaler",
+		resource: "horizontalpodautoscalers",
+		request: func(foo *samplecontroller.Foo) {
+			foo.Spec.Autoscaling = newAutoscaledFoo().Spec.Autoscaling
+		},
+		change: func(foo *samplecontroller.Foo) {
+			foo.Spec.Autoscaling.MaxReplicas = 20
+		},
+		remove: func(foo *samplecontroller.Foo) { foo.Spec.Autoscaling = nil },
+		// The Deployment takes its replicas from the Foo again.
+		removeAppliesDeployment: true,
+		applyConfig: func(foo *samplecontroller.Foo) (interface{}, error) {
+			return newAutoscalerApplyConfiguration(foo)
+		},
+		empty: func() childTestObject { return &autoscalingv2.HorizontalPodAutoscaler{} },
+		stored: func(t *testing.T, foo *samplecontroller.Foo) childTestObject {
+			return newAutoscaler(t, foo, *foo.Spec.Replicas)
+		},
+		add: func(f *fixture, obj childTestObject) {
+			f.hpaLister = append(f.hpaLister, obj.(*autoscalingv2.HorizontalPodAutoscaler))
+		},
+		withStatus: func(status samplecontroller.FooStatus, obj childTestObject) samplecontroller.FooStatus {
+			status.Autoscaling = autoscalerStatus(obj.(*autoscalingv2.HorizontalPodAutoscaler))
+			return status
+		},
+	}
+)
+
+// newFoo returns a Foo asking for a child of this kind.
+func (kind childTest) newFoo() *samplecontroller.Foo {
+	foo := newFoo("test", int32Ptr(2))
+	kind.request(foo)
+	samplescheme.Scheme.Default(foo)
+	return foo
+}
+
+// created returns the child of foo as applied by the controller, before the
+// API server or other controllers filled anything in.
+func (kind childTest) created(t *testing.T, foo *samplecontroller.Foo) childTestObject {
+	applyConfig, err := kind.applyConfig(foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := kind.empty()
+	if err := convert.ViaJSON(applyConfig, obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func (f *fixture) expectApplyChildAction(kind childTest, foo *samplecontroller.Foo) {
+	applyConfig, err := kind.applyConfig(foo)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	patch, err := json.Marshal(applyConfig)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.kubeactions = append(f.kubeactions, core.NewPatchAction(schema.GroupVersionResource{Resource: kind.resource}, foo.Namespace, foo.Name, types.ApplyPatchType, patch))
+}
+
+func (f *fixture) expectUpdateChildAction(kind childTest, obj childTestObject) {
+	f.kubeactions = append(f.kubeactions, core.NewUpdateAction(schema.GroupVersionResource{Resource: kind.resource}, obj.GetNamespace(), obj))
+}
+
+func (f *fixture) expectDeleteChildAction(kind childTest, obj childTestObject) {
+	f.kubeactions = append(f.kubeactions, core.NewDeleteAction(schema.GroupVersionResource{Resource: kind.resource}, obj.GetNamespace(), obj.GetName()))
+}
+
+func TestChildren(t *testing.T) {
+	tests := []struct {
+		name string
+		// prepare sets up f with foo and its child of the given kind and
+		// the actions expected from syncing foo, and returns foo.
+		prepare     func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo
+		expectError bool
+	}{
+		{
+			name: "Creates",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := kind.newFoo()
+				d := newDeployment(foo, "")
+				f.deploymentLister = append(f.deploymentLister, d)
+				f.kubeobjects = append(f.kubeobjects, d)
+
+				f.expectApplyChildAction(kind, foo)
+				f.expectApplyFooStatusAction(withStatus(foo, kind.withStatus(rollingOutStatus(foo), kind.created(t, foo))))
+				return foo
+			},
+		},
+		{
+			name: "UpToDate",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := kind.newFoo()
+				d := newDeployment(foo, "")
+				child := kind.stored(t, foo)
+				f.deploymentLister = append(f.deploymentLister, d)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, d, child)
+
+				f.expectApplyFooStatusAction(withStatus(foo, kind.withStatus(rollingOutStatus(foo), child)))
+				return foo
+			},
+		},
+		{
+			name: "Updates",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := kind.newFoo()
+				d := newDeployment(foo, "")
+				child := kind.stored(t, foo)
+				kind.change(foo)
+				f.deploymentLister = append(f.deploymentLister, d)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, d, child)
+
+				f.expectApplyChildAction(kind, foo)
+				f.expectApplyFooStatusAction(withStatus(foo, kind.withStatus(rollingOutStatus(foo), child)))
+				return foo
+			},
+		},
+		{
+			// Fields removed from the spec of the Foo are only noticed
+			// through the desired state hash.
+			name: "UpdatesRemovedFields",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := kind.newFoo()
+				d := newDeployment(foo, "")
+				changed := foo.DeepCopy()
+				kind.change(changed)
+				child := kind.stored(t, changed)
+				f.deploymentLister = append(f.deploymentLister, d)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, d, child)
+
+				f.expectApplyChildAction(kind, foo)
+				f.expectApplyFooStatusAction(withStatus(foo, kind.withStatus(rollingOutStatus(foo), child)))
+				return foo
+			},
+		},
+		{
+			name: "DeletesRemoved",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := kind.newFoo()
+				d := newDeployment(foo, "")
+				child := kind.stored(t, foo)
+				kind.remove(foo)
+				f.deploymentLister = append(f.deploymentLister, d)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, d, child)
+
+				if kind.removeAppliesDeployment {
+					f.expectApplyDeploymentAction(newDeployment(foo, ""))
+				}
+				f.expectDeleteChildAction(kind, child)
+				f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+				return foo
+			},
+		},
+		{
+			name: "NotControlledByUs",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := kind.newFoo()
+				d := newDeployment(foo, "")
+				child := kind.stored(t, foo)
+				child.SetOwnerReferences(nil)
+				f.deploymentLister = append(f.deploymentLister, d)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, d, child)
+
+				msg := fmt.Sprintf(MessageResourceExists, child.GetName())
+				f.expectApplyFooStatusAction(withStatus(foo, samplecontroller.FooStatus{
+					LastSyncTime: &syncTime,
+					Conditions: []metav1.Condition{
+						{Type: samplecontroller.FooResourceConflict, Status: metav1.ConditionTrue, Reason: ErrResourceExists, Message: msg, LastTransitionTime: syncTime},
+						{Type: samplecontroller.FooReady, Status: metav1.ConditionFalse, Reason: ErrResourceExists, Message: msg, LastTransitionTime: syncTime},
+						{Type: samplecontroller.FooSuspended, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+					},
+				}))
+				return foo
+			},
+			expectError: true,
+		},
+		{
+			name: "AdoptsRetained",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := kind.newFoo()
+				d := newDeployment(foo, "")
+				child := kind.stored(t, foo)
+				child.SetOwnerReferences(nil)
+				child.GetAnnotations()[samplecontroller.RetainedFromAnnotation] = foo.Name
+				f.deploymentLister = append(f.deploymentLister, d)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, d, child)
+
+				adopted := kind.stored(t, foo)
+				f.expectUpdateChildAction(kind, adopted)
+				f.expectApplyFooStatusAction(withStatus(foo, kind.withStatus(rollingOutStatus(foo), adopted)))
+				return foo
+			},
+		},
+		{
+			name: "FinalizeDeletes",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := newDeletingFoo(samplecontroller.DeletionPolicyDelete)
+				kind.request(foo)
+				samplescheme.Scheme.Default(foo)
+				child := kind.stored(t, foo)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, child)
+
+				f.expectDeleteChildAction(kind, child)
+				f.expectUpdateFooAction(withoutFinalizer(foo))
+				return foo
+			},
+		},
+		{
+			name: "FinalizeReleases",
+			prepare: func(t *testing.T, f *fixture, kind childTest) *samplecontroller.Foo {
+				foo := newDeletingFoo(samplecontroller.DeletionPolicyRetain)
+				kind.request(foo)
+				samplescheme.Scheme.Default(foo)
+				child := kind.stored(t, foo)
+				kind.add(f, child)
+				f.kubeobjects = append(f.kubeobjects, child)
+
+				released := kind.stored(t, foo)
+				released.SetOwnerReferences([]metav1.OwnerReference{})
+				released.GetAnnotations()[samplecontroller.RetainedFromAnnotation] = foo.Name
+				f.expectUpdateChildAction(kind, released)
+				f.expectUpdateFooAction(withoutFinalizer(foo))
+				return foo
+			},
+		},
+	}
+
+	for _, kind := range []childTest{serviceChild, disruptionBudgetChild, autoscalerChild} {
+		for _, test := range tests {
+			t.Run(kind.name+"/"+test.name, func(t *testing.T) {
+				f := newFixture(t)
+				_, ctx := ktesting.NewTestContext(t)
+				foo := test.prepare(t, f, kind)
+				f.fooLister = append(f.fooLister, foo)
+				f.objects = append(f.objects, foo)
+				if test.expectError {
+					f.runExpectError(ctx, getRef(foo, t))
+				} else {
+					f.run(ctx, getRef(foo, t))
+				}
+			})
+		}
+	}
+}
+
+func TestChildChangeEnqueuesFoo(t *testing.T) {
+	for _, kind := range []childTest{serviceChild, disruptionBudgetChild, autoscalerChild} {
+		t.Run(kind.name, func(t *testing.T) {
+			f := newFixture(t)
+			foo := kind.newFoo()
+			_, ctx := ktesting.NewTestContext(t)
+
+			f.fooLister = append(f.fooLister, foo)
+			c, _, _ := f.newController(ctx)
+
+			c.handleObject(kind.stored(t, foo))
+			if n := c.workqueue.Len(); n != 1 {
+				t.Errorf("expected the Foo owning the %s to be enqueued, got %d items", kind.name, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+	configscheme "k8s.io/sample-controller/pkg/apis/config/scheme"
+	configv1alpha1 "k8s.io/sample-controller/pkg/apis/config/v1alpha1"
+	"k8s.io/sample-controller/pkg/apis/config/validation"
+)
+
+// configOverrides copy the value of a flag into the configuration, by flag
+// name. Only the flags given on the command line are applied, so that the
+// defaults of the flags do not mask the values in the configuration file.
+var configOverrides = map[string]func(*config.SampleControllerConfiguration){}
+
+// addConfigFlags registers the flags overriding the settings of the
+// configuration file on fs.
+func addConfigFlags(fs *flag.FlagSet) {
+	workers := fs.Int("workers", 2, "The number of Foos synced concurrently.")
+	configOverrides["workers"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.Workers = int32(*workers)
+	}
+	resyncPeriod := fs.Duration("resync-period", 30*time.Second, "How often the informers replay their caches, syncing every Foo again.")
+	configOverrides["resync-period"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.ResyncPeriod.Duration = *resyncPeriod
+	}
+	namespaces := fs.String("namespaces", "", "Comma-separated list of the namespaces to watch. All namespaces are watched if empty.")
+	configOverrides["namespaces"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.Namespaces = parseNamespaces(*namespaces)
+	}
+	namespaceSelector := fs.String("namespace-selector", "", "Label selector of the namespaces to watch, e.g. samplecontroller.k8s.io/enabled=true. Namespaces opt in and out by being labelled. Mutually exclusive with --namespaces.")
+	configOverrides["namespace-selector"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.NamespaceSelector = *namespaceSelector
+	}
+	fooSelector := fs.String("foo-selector", "", "Label selector restricting the Foos the controller reconciles. All Foos are reconciled if empty. The selector is not applied to the Foo informer, so Foos whose labels stop matching can still be finalized.")
+	configOverrides["foo-selector"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.FooSelector = *fooSelector
+	}
+	baseDelay := fs.Duration("rate-limiter-base-delay", 5*time.Millisecond, "The delay before the first retry of a failed sync. It doubles with every further failure.")
+	configOverrides["rate-limiter-base-delay"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.RateLimiter.BaseDelay.Duration = *baseDelay
+	}
+	maxDelay := fs.Duration("rate-limiter-max-delay", 1000*time.Second, "The maximum delay between the retries of a Foo.")
+	configOverrides["rate-limiter-max-delay"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.RateLimiter.MaxDelay.Duration = *maxDelay
+	}
+	qps := fs.Float64("rate-limiter-qps", 50, "The rate at which Foos are requeued overall.")
+	configOverrides["rate-limiter-qps"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.RateLimiter.QPS = float32(*qps)
+	}
+	burst := fs.Int("rate-limiter-burst", 300, "The number of Foos that may be requeued at once above --rate-limiter-qps.")
+	configOverrides["rate-limiter-burst"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.RateLimiter.Burst = int32(*burst)
+	}
+	eventQPS := fs.Float64("event-qps", 1./300., "The rate at which Events about a single object are recorded once --event-burst is exhausted.")
+	configOverrides["event-qps"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.Events.QPS = float32(*eventQPS)
+	}
+	eventBurst := fs.Int("event-burst", 25, "The number of Events about a single object recorded before --event-qps applies.")
+	configOverrides["event-burst"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.Events.Burst = int32(*eventBurst)
+	}
+	eventDeduplicationWindow := fs.Duration("event-deduplication-window", 10*time.Minute, "How long an Event is not recorded again about the same object with the same reason and note.")
+	configOverrides["event-deduplication-window"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.Events.DeduplicationWindow.Duration = *eventDeduplicationWindow
+	}
+	// The log verbosity is set by the -v flag of klog, if it is registered
+	// on fs.
+	configOverrides["v"] = func(cfg *config.SampleControllerConfiguration) {
+		if v, err := strconv.ParseInt(fs.Lookup("v").Value.String(), 10, 32); err == nil {
+			cfg.LogVerbosity = int32(v)
+		}
+	}
+	suspendedNamespaces := fs.String("suspended-namespaces", "", "Comma-separated list of namespaces whose Foos are suspended.")
+	configOverrides["suspended-namespaces"] = func(cfg *config.SampleControllerConfiguration) {
+		cfg.SuspendedNamespaces = parseNamespaces(*suspendedNamespaces)
+	}
+}
+
+// loadConfiguration reads the configuration file at path, or returns the
+// default configuration if path is empty, applies the flags set on fs over it
+// and validates the result.
+func loadConfiguration(path string, fs *flag.FlagSet) (*config.SampleControllerConfiguration, error) {
+	cfg, err := readConfigurationFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if override, ok := configOverrides[f.Name]; ok {
+			override(cfg)
+		}
+	})
+	if errs := validation.ValidateSampleControllerConfiguration(cfg); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return cfg, nil
+}
+
+// readConfigurationFile decodes the configuration file at path into the
+// internal version, with defaults applied.
+func readConfigurationFile(path string) (*config.SampleControllerConfiguration, error) {
+	if path == "" {
+		return defaultConfiguration()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	obj, gvk, err := configscheme.Codecs.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", path, err)
+	}
+	cfg, ok := obj.(*config.SampleControllerConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("%s holds a %s, expected a SampleControllerConfiguration", path, gvk)
+	}
+	return cfg, nil
+}
+
+// defaultConfiguration returns the configuration used without a
+// configuration file.
+func defaultConfiguration() (*config.SampleControllerConfiguration, error) {
+	versioned := &configv1alpha1.SampleControllerConfiguration{}
+	configscheme.Scheme.Default(versioned)
+	cfg := &config.SampleControllerConfiguration{}
+	if err := configscheme.Scheme.Convert(versioned, cfg, nil); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+)
+
+// newConfigFlagSet returns a FlagSet with the configuration flags, parsed
+// from args.
+func newConfigFlagSet(t *testing.T, args ...string) *flag.FlagSet {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	addConfigFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaultConfiguration(t *testing.T) {
+	cfg, err := loadConfiguration("", newConfigFlagSet(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config.SampleControllerConfiguration{
+		Workers:      2,
+		ResyncPeriod: metav1.Duration{Duration: 30 * time.Second},
+		RateLimiter: config.RateLimiterConfiguration{
+			BaseDelay: metav1.Duration{Duration: 5 * time.Millisecond},
+			MaxDelay:  metav1.Duration{Duration: 1000 * time.Second},
+			QPS:       50,
+			Burst:     300,
+		},
+		Events: config.EventsConfiguration{
+			QPS:                 1. / 300.,
+			Burst:               25,
+			DeduplicationWindow: metav1.Duration{Duration: 10 * time.Minute},
+			CacheSize:           4096,
+		},
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("expected the defaults\n%+v\ngot\n%+v", expected, cfg)
+	}
+}
+
+func TestLoadConfigurationFile(t *testing.T) {
+	path := writeConfigFile(t, `apiVersion: config.samplecontroller.k8s.io/v1alpha1
+kind: SampleControllerConfiguration
+workers: 4
+namespaces: [team-a]
+rateLimiter:
+  maxDelay: 5m
+`)
+
+	cfg, err := loadConfiguration(path, newConfigFlagSet(t, "--workers=8", "--rate-limiter-qps=10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flags override the file, which overrides the defaults.
+	if cfg.Workers != 8 {
+		t.Errorf("expected the workers flag to override the file, got %d", cfg.Workers)
+	}
+	if cfg.RateLimiter.QPS != 10 {
+		t.Errorf("expected the QPS flag to apply, got %v", cfg.RateLimiter.QPS)
+	}
+	if cfg.RateLimiter.MaxDelay.Duration != 5*time.Minute {
+		t.Errorf("expected the max delay of the file, got %v", cfg.RateLimiter.MaxDelay)
+	}
+	if !reflect.DeepEqual(cfg.Namespaces, []string{"team-a"}) {
+		t.Errorf("expected the namespaces of the file, got %v", cfg.Namespaces)
+	}
+	if cfg.RateLimiter.BaseDelay.Duration != 5*time.Millisecond || cfg.ResyncPeriod.Duration != 30*time.Second {
+		t.Errorf("expected unset settings to be defaulted, got %+v", cfg)
+	}
+}
+
+func TestLoadInvalidConfiguration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		args     []string
+		expected string
+	}{
+		{
+			name: "unknown field",
+			content: `apiVersion: config.samplecontroller.k8s.io/v1alpha1
+kind: SampleControllerConfiguration
+wrokers: 4
+`,
+			expected: `unknown field "wrokers"`,
+		},
+		{
+			name: "unknown version",
+			content: `apiVersion: config.samplecontroller.k8s.io/v1
+kind: SampleControllerConfiguration
+`,
+			expected: "no kind",
+		},
+		{
+			name: "invalid flag",
+			content: `apiVersion: config.samplecontroller.k8s.io/v1alpha1
+kind: SampleControllerConfiguration
+namespaces: [team-a]
+`,
+			args:     []string{"--namespace-selector=samplecontroller.k8s.io/enabled=true"},
+			expected: "namespaceSelector: Forbidden",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadConfiguration(writeConfigFile(t, tc.content), newConfigFlagSet(t, tc.args...))
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -19,51 +19,145 @@ package main
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"golang.org/x/time/rate"
-
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/dump"
+	"k8s.io/apimachinery/pkg/util/rand"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
-	appsinformers "k8s.io/client-go/informers/apps/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
 
 	"k8s.io/client-go/kubernetes"        // 导入 Kubernetes 自定义客户端库
 	"k8s.io/client-go/kubernetes/scheme" // 导入 Kubernetes 原生资源的类型定义（Scheme 是所有资源类型的注册表）
-	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/csaupgrade"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 
+	"k8s.io/sample-controller/pkg/apis/config"
 	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/convert"
+	samplev1alpha1ac "k8s.io/sample-controller/pkg/generated/applyconfiguration/samplecontroller/v1alpha1"
 	clientset "k8s.io/sample-controller/pkg/generated/clientset/versioned"
 	samplescheme "k8s.io/sample-controller/pkg/generated/clientset/versioned/scheme"
-	informers "k8s.io/sample-controller/pkg/generated/informers/externalversions/samplecontroller/v1alpha1"
 	listers "k8s.io/sample-controller/pkg/generated/listers/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/metrics"
+	"k8s.io/sample-controller/pkg/tracing"
 )
 
 const controllerAgentName = "sample-controller"
 
 const (
-	// SuccessSynced is used as part of the Event 'reason' when a Foo is synced
-	SuccessSynced = "Synced"
+	// DeploymentCreated is used as part of the Event 'reason' when the
+	// Deployment of a Foo is created
+	DeploymentCreated = "DeploymentCreated"
+	// DeploymentScaled is used as part of the Event 'reason' when the
+	// replicas of the Deployment of a Foo change
+	DeploymentScaled = "DeploymentScaled"
+	// DeploymentUpdated is used as part of the Event 'reason' when the
+	// Deployment of a Foo is updated from a change of the Foo
+	DeploymentUpdated = "DeploymentUpdated"
 	// ErrResourceExists is used as part of the Event 'reason' when a Foo fails
-	// to sync due to a Deployment of the same name already existing.
+	// to sync due to a Deployment, Service, PodDisruptionBudget or
+	// HorizontalPodAutoscaler of the same name already existing.
 	ErrResourceExists = "ErrResourceExists"
 
+	// StaleDeploymentDeleted is used as part of the Event 'reason' when a
+	// Deployment the Foo no longer names is deleted
+	StaleDeploymentDeleted = "StaleDeploymentDeleted"
+
+	// ErrMissingDependency is used as part of the Event 'reason' when a Foo
+	// is not rolled out because its pods reference objects that do not exist.
+	ErrMissingDependency = "ErrMissingDependency"
+
+	// ErrFieldConflict is used as part of the Event 'reason' when a Foo fails
+	// to sync because another field manager owns fields of its Deployment.
+	ErrFieldConflict = "ErrFieldConflict"
+
+	// MessageDeploymentCreated is the message used for Events when the
+	// Deployment of a Foo is created
+	MessageDeploymentCreated = "Created Deployment %q with %d replicas"
+	// MessageDeploymentScaled is the message used for Events when the
+	// replicas of the Deployment of a Foo change
+	MessageDeploymentScaled = "Scaled Deployment %q from %d to %d replicas"
+	// MessageDeploymentUpdated is the message used for Events when the
+	// Deployment of a Foo is updated from a change of the Foo
+	MessageDeploymentUpdated = "Updated %s of Deployment %q"
 	// MessageResourceExists is the message used for Events when a resource
 	// fails to sync due to a Deployment already existing
 	MessageResourceExists = "Resource %q already exists and is not managed by Foo"
-	// MessageResourceSynced is the message used for an Event fired when a Foo
-	// is synced successfully
-	MessageResourceSynced = "Foo synced successfully"
+	// MessageStaleDeploymentDeleted is the message used for Events when a
+	// Deployment left behind by a rename is deleted
+	MessageStaleDeploymentDeleted = "Deleted Deployment %q replaced by %q"
+	// MessageMissingDependency is the message used for Events when the pods
+	// of a Foo reference ConfigMaps or Secrets that do not exist
+	MessageMissingDependency = "Waiting for %s referenced by the pod template"
+	// MessageFieldConflict is the message used for Events when applying a
+	// Deployment conflicts with another field manager
+	MessageFieldConflict = "Failed to apply Deployment %q: %v"
 	// FieldManager distinguishes this controller from other things writing to API objects
 	FieldManager = controllerAgentName
+
+	// DesiredStateHashAnnotation is set on the Deployments, Services,
+	// PodDisruptionBudgets and HorizontalPodAutoscalers created for a Foo and
+	// records a hash of the spec they were built from, so changes to the Foo can be told apart from
+	// changes made by others.
+	DesiredStateHashAnnotation = "samplecontroller.k8s.io/desired-state-hash"
+)
+
+// Actions reported by the Events of the controller, naming what it did, or
+// failed to do, about the object an Event is about.
+const (
+	ActionCreate  = "Create"
+	ActionScale   = "Scale"
+	ActionUpdate  = "Update"
+	ActionDelete  = "Delete"
+	ActionSync    = "Sync"
+	ActionRollout = "Rollout"
+	ActionReload  = "Reload"
+)
+
+// Reasons used for the conditions in FooStatus.
+const (
+	// ReasonRolloutComplete is used when all replicas run the current template
+	// and are available.
+	ReasonRolloutComplete = "RolloutComplete"
+	// ReasonRollingOut is used while the Deployment is still converging.
+	ReasonRollingOut = "RollingOut"
+	// ReasonReplicasUnavailable is used when fewer replicas than desired are
+	// available.
+	ReasonReplicasUnavailable = "ReplicasUnavailable"
+	// ReasonAsExpected is used for negative-polarity conditions that are not
+	// currently in effect.
+	ReasonAsExpected = "AsExpected"
+	// ReasonDeploymentControlled is used when the Deployment named by the Foo
+	// is controlled by it.
+	ReasonDeploymentControlled = "DeploymentControlled"
 )
 
 // Controller is the controller implementation for Foo resources
@@ -73,68 +167,167 @@ type Controller struct {
 	// sampleclientset is a clientset for our own API group // 自定义 API 组的 clientset
 	sampleclientset clientset.Interface
 
-	deploymentsLister appslisters.DeploymentLister // Deployment列表对象
-	deploymentsSynced cache.InformerSynced // Deployment同步状态
-	foosLister        listers.FooLister // Foo列表对象
-	foosSynced        cache.InformerSynced // Foo同步状态
+	deploymentsLister  appslisters.DeploymentLister                     // Deployment列表对象
+	deploymentsIndexer cache.Indexer                                    // Deployment 按控制者 UID 建立的索引
+	deploymentsSynced  cache.InformerSynced                             // Deployment同步状态
+	servicesLister     corelisters.ServiceLister                        // Service列表对象
+	servicesSynced     cache.InformerSynced                             // Service同步状态
+	pdbsLister         policylisters.PodDisruptionBudgetLister          // PodDisruptionBudget列表对象
+	pdbsSynced         cache.InformerSynced                             // PodDisruptionBudget同步状态
+	hpasLister         autoscalinglisters.HorizontalPodAutoscalerLister // HorizontalPodAutoscaler列表对象
+	hpasSynced         cache.InformerSynced                             // HorizontalPodAutoscaler同步状态
+	configMapsLister   corelisters.ConfigMapLister                      // ConfigMap列表对象
+	configMapsSynced   cache.InformerSynced                             // ConfigMap同步状态
+	secretsLister      corelisters.SecretLister                         // Secret列表对象
+	secretsSynced      cache.InformerSynced                             // Secret同步状态
+	foosLister         listers.FooLister                                // Foo列表对象
+	foosIndexer        cache.Indexer                                    // Foo 按引用的 ConfigMap 和 Secret 建立的索引
+	foosSynced         cache.InformerSynced                             // Foo同步状态
+	// fooSelector selects the Foos reconciled. All Foos in the namespaces
+	// watched are cached, so that a Foo losing the labels that put it in
+	// scope can still be finalized.
+	fooSelector labels.Selector
+	// namespaceSynced reports whether the informers of a namespace have
+	// synced. The Foos of namespaces added while the controller runs are
+	// only queued once they have.
+	namespaceSynced func(namespace string) bool
 
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
 	// means we can ensure we only process a fixed amount of resources at a
 	// time, and makes it easy to ensure we are never processing the same item
 	// simultaneously in two different workers.
-	workqueue workqueue.TypedRateLimitingInterface[cache.ObjectName]// 工作队列意味着每次只能处理一次事件
-	// recorder is an event recorder for recording Event resources to the Kubernetes API.
+	workqueue *tracedQueue // 工作队列意味着每次只能处理一次事件
+	// rateLimiter delays the retries of the workqueue.
+	rateLimiter *rateLimiter
+	// workers is the number of Foos synced concurrently. Run starts the
+	// workers with workersCtx, and a reload of the configuration starts or
+	// retires workers while it runs; workerCancels retire them.
+	workersLock   sync.Mutex
+	workers       int
+	workerCancels []context.CancelFunc
+	workersCtx    context.Context
+	// suspendedNamespaces are the namespaces whose Foos are suspended by the
+	// configuration.
+	suspendedNamespaces atomic.Pointer[sets.Set[string]]
+	// config is the configuration in effect, replaced by reloads.
+	reloadLock sync.Mutex
+	config     *config.SampleControllerConfiguration
+	// reloadTarget is the object the Events about configuration reloads are
+	// recorded on. No Event is recorded if it is nil.
+	reloadTarget *corev1.ObjectReference
+	// recorder is an event recorder for recording events.k8s.io/v1 Event resources to the Kubernetes API.
 	// 事件记录器, 用于记录事件资源到 Kubernetes API
-	// record 上报,处理及打印事件, 用 kubectl get events可以查看上报的事件. 
-	recorder record.EventRecorder 
+	// record 上报,处理及打印事件, 用 kubectl get events可以查看上报的事件.
+	recorder events.EventRecorder
+	// clock is used to timestamp status updates.
+	clock clock.PassiveClock
+	// tracer traces the reconciliations of Foos.
+	tracer trace.Tracer
+
+	// cachesSynced is set once Run has synced the informer caches.
+	cachesSynced atomic.Bool
+	// syncStarts holds the start time of every sync in progress, to tell
+	// whether the workers are wedged.
+	syncsLock  sync.Mutex
+	syncStarts map[cache.ObjectName]time.Time
 }
 
-// NewController returns a new sample controller
+// NewController returns a new sample controller watching the namespaces of
+// scope.
 func NewController(
 	ctx context.Context,
 	kubeclientset kubernetes.Interface,
 	sampleclientset clientset.Interface,
-	deploymentInformer appsinformers.DeploymentInformer,
-	fooInformer informers.FooInformer) *Controller {
+	scope *informerScope,
+	cfg *config.SampleControllerConfiguration) *Controller {
 	logger := klog.FromContext(ctx)
 
 	// Create event broadcaster
 	// Add sample-controller types to the default Kubernetes Scheme so Events can be
 	// logged for sample-controller types.
-	utilruntime.Must(samplescheme.AddToScheme(scheme.Scheme)) // 添加自定义资源组别到默认的 Kubernetes Scheme
-	logger.V(4).Info("Creating event broadcaster")
-
-	eventBroadcaster := record.NewBroadcaster(record.WithContext(ctx)) // 创建事件广播器
-	eventBroadcaster.StartStructuredLogging(0) // 记录到本地日志
-	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")}) // 上报 events 到 apiserver
-	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName}) // 创建 EventRecorder
-	ratelimiter := workqueue.NewTypedMaxOfRateLimiter(
-		workqueue.NewTypedItemExponentialFailureRateLimiter[cache.ObjectName](5*time.Millisecond, 1000*time.Second),
-		&workqueue.TypedBucketRateLimiter[cache.ObjectName]{Limiter: rate.NewLimiter(rate.Limit(50), 300)},
-	)
+	utilruntime.Must(samplescheme.AddToScheme(scheme.Scheme))     // 添加自定义资源组别到默认的 Kubernetes Scheme
+	recorder := newEventRecorder(ctx, kubeclientset, &cfg.Events) // 创建 EventRecorder, 上报 events.k8s.io/v1 事件到 apiserver
+	ratelimiter := newRateLimiter(&cfg.RateLimiter)
+	// The selector is validated with the rest of the configuration.
+	fooSelector, err := labels.Parse(cfg.FooSelector)
+	utilruntime.Must(err)
 
 	controller := &Controller{
-		kubeclientset:     kubeclientset,
-		sampleclientset:   sampleclientset,
-		deploymentsLister: deploymentInformer.Lister(),
-		deploymentsSynced: deploymentInformer.Informer().HasSynced,
-		foosLister:        fooInformer.Lister(),
-		foosSynced:        fooInformer.Informer().HasSynced,
-		workqueue:         workqueue.NewTypedRateLimitingQueue(ratelimiter),
-		recorder:          recorder,
+		kubeclientset:      kubeclientset,
+		sampleclientset:    sampleclientset,
+		deploymentsLister:  appslisters.NewDeploymentLister(scope.indexer((*namespaceInformers).deployments)),
+		deploymentsIndexer: scope.indexer((*namespaceInformers).deployments),
+		deploymentsSynced:  scope.hasSynced((*namespaceInformers).deployments),
+		servicesLister:     corelisters.NewServiceLister(scope.indexer((*namespaceInformers).services)),
+		servicesSynced:     scope.hasSynced((*namespaceInformers).services),
+		pdbsLister:         policylisters.NewPodDisruptionBudgetLister(scope.indexer((*namespaceInformers).podDisruptionBudgets)),
+		pdbsSynced:         scope.hasSynced((*namespaceInformers).podDisruptionBudgets),
+		hpasLister:         autoscalinglisters.NewHorizontalPodAutoscalerLister(scope.indexer((*namespaceInformers).horizontalPodAutoscalers)),
+		hpasSynced:         scope.hasSynced((*namespaceInformers).horizontalPodAutoscalers),
+		configMapsLister:   corelisters.NewConfigMapLister(scope.indexer((*namespaceInformers).configMaps)),
+		configMapsSynced:   scope.hasSynced((*namespaceInformers).configMaps),
+		secretsLister:      corelisters.NewSecretLister(scope.indexer((*namespaceInformers).secrets)),
+		secretsSynced:      scope.hasSynced((*namespaceInformers).secrets),
+		foosLister:         listers.NewFooLister(scope.indexer((*namespaceInformers).foos)),
+		foosIndexer:        scope.indexer((*namespaceInformers).foos),
+		foosSynced:         scope.hasSynced((*namespaceInformers).foos),
+		fooSelector:        fooSelector,
+		namespaceSynced:    scope.namespaceSynced,
+		rateLimiter:        ratelimiter,
+		workqueue: newTracedQueue(ratelimiter, workqueue.TypedRateLimitingQueueConfig[cache.ObjectName]{
+			Name: "foos",
+		}),
+		workers:    int(cfg.Workers),
+		config:     cfg,
+		recorder:   recorder,
+		clock:      clock.RealClock{},
+		tracer:     tracing.Tracer(),
+		syncStarts: map[cache.ObjectName]time.Time{},
 	}
 
+	controller.suspendedNamespaces.Store(ptr.To(sets.New(cfg.SuspendedNamespaces...)))
+
 	logger.Info("Setting up event handlers")
+	scope.watch(controller.watchNamespace)
+	// The Foos of namespaces added while the controller runs are queued once
+	// the informers of the namespace have synced.
+	scope.onSynced(func(n *namespaceInformers) {
+		controller.enqueueNamespace(ctx, n.namespace)
+	})
+
+	return controller
+}
+
+// watchNamespace sets up the indexes and event handlers of the informers of
+// a namespace in the controller's scope.
+func (c *Controller) watchNamespace(n *namespaceInformers) {
+	// Index Deployments by the UID of their controller, so the Deployments of
+	// a Foo can be found regardless of their name.
+	utilruntime.Must(n.deployments().AddIndexers(cache.Indexers{
+		controllerUIDIndex: controllerUIDIndexFunc,
+	}))
+	// Index Foos by the ConfigMaps and Secrets their pods reference, so a
+	// change to one of them finds the Foos to roll.
+	utilruntime.Must(n.foos().AddIndexers(cache.Indexers{
+		configMapIndex: referenceIndexFunc(func(refs podReferences) map[string]bool { return refs.configMaps }),
+		secretIndex:    referenceIndexFunc(func(refs podReferences) map[string]bool { return refs.secrets }),
+	}))
 
 	// 对资源的创建/更新/删除绑定方法, 也就是实现逻辑的入口.
 
 	// Set up an event handler for when Foo resources change
-	fooInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: controller.enqueueFoo,
+	n.foos().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueFoo,
 		UpdateFunc: func(old, new interface{}) {
-			controller.enqueueFoo(new)
+			// The status written by the controller itself is no reason to
+			// sync the Foo again.
+			if !fooChanged(old.(*samplev1alpha1.Foo), new.(*samplev1alpha1.Foo)) {
+				return
+			}
+			c.enqueueFoo(new)
 		},
+		DeleteFunc: c.enqueueFoo,
 	})
 	// Set up an event handler for when Deployment resources change. This
 	// handler will lookup the owner of the given Deployment, and if it is
@@ -162,8 +355,8 @@ func NewController(
 	// 总结：查看handleObject方法可以看到：当Deployment变化时，它会检查Deployment是否有一个Foo类型的所有者，如果有，就将该Foo资源加入到工作队列中进行处理。
 	// 这是Kubernetes控制器的一个最佳实践 - 不仅监听你负责管理的主资源（Foo），还要监听由它创建的所有下游资源（Deployment），以确保整个系统的状态一致性。
 
-	deploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: controller.handleObject,
+	n.deployments().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleObject,
 		UpdateFunc: func(old, new interface{}) {
 			newDepl := new.(*appsv1.Deployment)
 			oldDepl := old.(*appsv1.Deployment)
@@ -172,19 +365,78 @@ func NewController(
 				// Two different versions of the same Deployment will always have different RVs.
 				return
 			}
-			controller.handleObject(new)
+			c.handleObject(new)
 		},
-		DeleteFunc: controller.handleObject,
+		DeleteFunc: c.handleObject,
 	})
-
-	return controller
+	// Services are handled the same way as Deployments.
+	n.services().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleObject,
+		UpdateFunc: func(old, new interface{}) {
+			newSvc := new.(*corev1.Service)
+			oldSvc := old.(*corev1.Service)
+			if newSvc.ResourceVersion == oldSvc.ResourceVersion {
+				return
+			}
+			c.handleObject(new)
+		},
+		DeleteFunc: c.handleObject,
+	})
+	// So are PodDisruptionBudgets, whose status changes as pods come and go.
+	n.podDisruptionBudgets().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleObject,
+		UpdateFunc: func(old, new interface{}) {
+			newPDB := new.(*policyv1.PodDisruptionBudget)
+			oldPDB := old.(*policyv1.PodDisruptionBudget)
+			if newPDB.ResourceVersion == oldPDB.ResourceVersion {
+				return
+			}
+			c.handleObject(new)
+		},
+		DeleteFunc: c.handleObject,
+	})
+	// And HorizontalPodAutoscalers, whose status follows the replica count
+	// they calculate.
+	n.horizontalPodAutoscalers().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleObject,
+		UpdateFunc: func(old, new interface{}) {
+			newHPA := new.(*autoscalingv2.HorizontalPodAutoscaler)
+			oldHPA := old.(*autoscalingv2.HorizontalPodAutoscaler)
+			if newHPA.ResourceVersion == oldHPA.ResourceVersion {
+				return
+			}
+			c.handleObject(new)
+		},
+		DeleteFunc: c.handleObject,
+	})
+	// ConfigMaps and Secrets are not owned by Foos; the Foos referencing
+	// them are found through the Foo indexes instead.
+	for index, informer := range map[string]cache.SharedIndexInformer{
+		configMapIndex: n.configMaps(),
+		secretIndex:    n.secrets(),
+	} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.handleDependency(index, obj)
+			},
+			UpdateFunc: func(old, new interface{}) {
+				if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+					return
+				}
+				c.handleDependency(index, new)
+			},
+			DeleteFunc: func(obj interface{}) {
+				c.handleDependency(index, obj)
+			},
+		})
+	}
 }
 
 // Run will set up the event handlers for types we are interested in, as well
 // as syncing informer caches and starting workers. It will block until stopCh
 // is closed, at which point it will shutdown the workqueue and wait for
 // workers to finish processing their current work items.
-func (c *Controller) Run(ctx context.Context, workers int) error {
+func (c *Controller) Run(ctx context.Context) error {
 	defer utilruntime.HandleCrash()
 	defer c.workqueue.ShutDown()
 	logger := klog.FromContext(ctx)
@@ -196,15 +448,13 @@ func (c *Controller) Run(ctx context.Context, workers int) error {
 	logger.Info("Waiting for informer caches to sync")
 
 	// 等待完成同步
-	if ok := cache.WaitForCacheSync(ctx.Done(), c.deploymentsSynced, c.foosSynced); !ok {
+	if ok := cache.WaitForCacheSync(ctx.Done(), c.deploymentsSynced, c.servicesSynced, c.pdbsSynced, c.hpasSynced, c.configMapsSynced, c.secretsSynced, c.foosSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
+	c.cachesSynced.Store(true)
 
-	logger.Info("Starting workers", "count", workers)
-	// Launch two workers to process Foo resources
-	for i := 0; i < workers; i++ {
-		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
-	}
+	// Launch the configured number of workers to process Foo resources
+	c.startWorkers(ctx)
 
 	logger.Info("Started workers")
 	<-ctx.Done()
@@ -215,9 +465,18 @@ func (c *Controller) Run(ctx context.Context, workers int) error {
 
 // runWorker is a long-running function that will continually call the
 // processNextWorkItem function in order to read and process a message on the
-// workqueue.
-func (c *Controller) runWorker(ctx context.Context) {
-	for c.processNextWorkItem(ctx) {
+// workqueue, until stop is closed. The Foo being synced at that time, if any,
+// is finished first.
+func (c *Controller) runWorker(ctx context.Context, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if !c.processNextWorkItem(ctx) {
+			return
+		}
 	}
 }
 
@@ -240,8 +499,19 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// period.
 	defer c.workqueue.Done(objRef)
 
+	// The reconciliation is traced from the time the Foo was ready to be
+	// synced, and its log lines carry the IDs of the trace.
+	ctx, span := c.startReconcileSpan(ctx, objRef)
+	ctx = klog.NewContext(ctx, tracing.LoggerWithSpan(ctx, logger))
+	logger = klog.FromContext(ctx)
+
 	// Run the syncHandler, passing it the structured reference to the object to be synced.
+	start := time.Now()
+	done := c.startSync(objRef)
 	err := c.syncHandler(ctx, objRef)
+	done()
+	metrics.ObserveReconcile(reconcileResult(err), time.Since(start))
+	endSpan(span, err)
 	if err == nil {
 		// If no error occurs then we Forget this item so it does not
 		// get queued again until another change happens.
@@ -263,16 +533,35 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	return true
 }
 
+// reconcileResult classifies the error returned by syncHandler for the
+// reconcile metrics.
+func reconcileResult(err error) string {
+	switch {
+	case err == nil:
+		return metrics.ResultSuccess
+	case errors.IsConflict(err):
+		return metrics.ResultConflict
+	case errors.IsTooManyRequests(err), errors.IsServerTimeout(err), errors.IsTimeout(err), errors.IsServiceUnavailable(err):
+		return metrics.ResultRequeue
+	default:
+		return metrics.ResultError
+	}
+}
+
 // 根据 Foo 对 deployment 进行操作, CRD 逻辑实现.
 // syncHandler compares the actual state with the desired, and attempts to
 // converge the two. It then updates the Status block of the Foo resource
 // with the current status of the resource.
-func (c *Controller) syncHandler(ctx context.Context, objectRef cache.ObjectName) error {
+func (c *Controller) syncHandler(ctx context.Context, objectRef cache.ObjectName) (err error) {
+	ctx, span := c.tracer.Start(ctx, SpanSync)
+	defer func() { endSpan(span, err) }()
 	logger := klog.LoggerWithValues(klog.FromContext(ctx), "objectRef", objectRef)
 
 	// Get the Foo resource with this namespace/name
 	// Lister同步服务端的状态, 不用再跟服务端进行通信
+	_, lookup := c.tracer.Start(ctx, SpanGetFoo)
 	foo, err := c.foosLister.Foos(objectRef.Namespace).Get(objectRef.Name)
+	lookup.End()
 	if err != nil {
 		// The Foo resource may no longer exist, in which case we stop
 		// processing.
@@ -283,22 +572,89 @@ func (c *Controller) syncHandler(ctx context.Context, objectRef cache.ObjectName
 
 		return err
 	}
+	// Only the Foos matching the selector are reconciled, the others are left
+	// to other controllers. A Foo that stopped matching it still has to be
+	// finalized when it is deleted though, or its deletion never completes.
+	if !c.fooSelector.Matches(labels.Set(foo.Labels)) && (foo.DeletionTimestamp == nil || !hasFinalizer(foo)) {
+		logger.V(4).Info("Skipping Foo not matching the selector")
+		return nil
+	}
+
+	// Foos stored before the defaulting webhook was installed may lack the
+	// defaulted fields, so the same defaults are applied here. The pod
+	// template label the webhook adds to new Foos is not, as it would roll
+	// the pods of existing ones. The lister's copy must not be modified.
+	stored := foo
+	foo = foo.DeepCopy()
+	samplescheme.Scheme.Default(foo)
+
+	// A Foo that is being deleted only needs its children taken care of
+	// according to its deletion policy before the finalizer is dropped.
+	if foo.DeletionTimestamp != nil {
+		return c.finalizeFoo(ctx, foo)
+	}
+
+	// A suspended Foo only has its status reported, so that changes made to
+	// its objects by hand, e.g. during an incident, are left alone.
+	suspended := c.fooSuspendedCondition(foo)
+	c.recordSuspendTransition(foo, suspended)
+	if suspended.Status == metav1.ConditionTrue {
+		return c.syncSuspended(ctx, foo, suspended)
+	}
+
+	// A Foo suspended since its creation only gets the finalizer once it is
+	// resumed, as it has no children to clean up until then. The finalizer is
+	// added to the Foo as it is stored: updating the defaulted copy would
+	// store the defaults too and bump its generation.
+	if !hasFinalizer(foo) {
+		foo, err = c.addFinalizer(ctx, stored)
+		if err != nil {
+			return err
+		}
+		samplescheme.Scheme.Default(foo)
+	}
 
 	deploymentName := foo.Spec.DeploymentName
-	if deploymentName == "" {
-		// We choose to absorb the error here as the worker would requeue the
-		// resource otherwise. Instead, the next time the resource is updated
-		// the resource will be queued again.
-		utilruntime.HandleErrorWithContext(ctx, nil, "Deployment name missing from object reference", "objectReference", objectRef)
-		return nil
+
+	// The pods are only rolled out once every ConfigMap and Secret they need
+	// exists. The informers enqueue the Foo again when they are created.
+	_, lookup = c.tracer.Start(ctx, SpanResolveDependencies)
+	configHash, missing, err := c.dependencyHash(foo)
+	lookup.End()
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		msg := fmt.Sprintf(MessageMissingDependency, strings.Join(missing, ", "))
+		return c.updateFooConflictStatus(ctx, foo, nil, samplev1alpha1.FooMissingDependency, ErrMissingDependency, msg)
+	}
+	desired := newDeployment(foo, configHash)
+	// A pod template that failed to roll out is replaced by the last good
+	// one until the template of the Foo changes again.
+	hash := templateHash(desired)
+	rolledBack := rollbackTemplate(foo, desired, hash)
+	// With a canary strategy a new template runs on a canary Deployment
+	// first, while the Deployment keeps the last good one.
+	canary, err := c.progressCanary(ctx, foo, desired, hash, rolledBack)
+	if err != nil {
+		return err
+	}
+	stableHash := hash
+	if canaryHoldsStableTemplate(foo, hash) {
+		stableHash = foo.Status.Rollout.LastGoodTemplateHash
 	}
 
 	// 获取 deployment 类型, 如果没有找到, 则对服务端创建 deployment
 	// Get the deployment with the name specified in Foo.spec
+	_, lookup = c.tracer.Start(ctx, SpanGetDeployment)
 	deployment, err := c.deploymentsLister.Deployments(foo.Namespace).Get(deploymentName)
+	lookup.End()
 	// If the resource doesn't exist, we'll create it
 	if errors.IsNotFound(err) {
-		deployment, err = c.kubeclientset.AppsV1().Deployments(foo.Namespace).Create(ctx, newDeployment(foo), metav1.CreateOptions{FieldManager: FieldManager})
+		deployment, err = c.applyDeployment(ctx, desired, false)
+		if err == nil {
+			c.recorder.Eventf(foo, deployment, corev1.EventTypeNormal, DeploymentCreated, ActionCreate, MessageDeploymentCreated, deployment.Name, desiredReplicas(deployment))
+		}
 	}
 
 	// If an error occurs during Get/Create, we'll requeue the item so we can
@@ -308,67 +664,364 @@ func (c *Controller) syncHandler(ctx context.Context, objectRef cache.ObjectName
 		return err
 	}
 
+	// A Deployment retained by a previous incarnation of this Foo is adopted
+	// back instead of being reported as a conflict.
+	if canAdopt(foo, deployment) {
+		deployment, err = c.deployments().adopt(ctx, foo, deployment)
+		if err != nil {
+			return err
+		}
+	}
+
 	// If the Deployment is not controlled by this Foo resource, we should log
 	// a warning to the event recorder and return error msg.
 	if !metav1.IsControlledBy(deployment, foo) {
 		msg := fmt.Sprintf(MessageResourceExists, deployment.Name)
-		c.recorder.Event(foo, corev1.EventTypeWarning, ErrResourceExists, msg)
+		if err := c.updateFooConflictStatus(ctx, foo, deployment, samplev1alpha1.FooResourceConflict, ErrResourceExists, msg); err != nil {
+			return err
+		}
 		return fmt.Errorf("%s", msg)
 	}
 
+	// While the Foo is autoscaled its HorizontalPodAutoscaler decides the
+	// replicas, so their changes are neither drift nor reverted.
+	keepAutoscaledReplicas(foo, desired, deployment)
+
 	// 如果 foo 的 relicas 字段不等于 deployment 的 replicas 字段, 则修改 deployment 的 replicas 数量
-	// If this number of the replicas on the Foo resource is specified, and the
-	// number does not equal the current desired replicas on the Deployment, we
-	// should update the Deployment resource.
-	if foo.Spec.Replicas != nil && *foo.Spec.Replicas != *deployment.Spec.Replicas {
-		logger.V(4).Info("Update deployment resource", "currentReplicas", *deployment.Spec.Replicas, "desiredReplicas", *foo.Spec.Replicas)
-		deployment, err = c.kubeclientset.AppsV1().Deployments(foo.Namespace).Update(ctx, newDeployment(foo), metav1.UpdateOptions{FieldManager: FieldManager})
+	// If the Foo has changed since the Deployment was last written, we should
+	// update the Deployment resource. Otherwise any field the controller sets
+	// that was changed by someone else is drift, which is reverted by forcing
+	// the apply.
+	specChanged := deployment.Annotations[DesiredStateHashAnnotation] != desired.Annotations[DesiredStateHashAnnotation]
+	var drifted []string
+	if !specChanged {
+		drifted, err = deploymentDrift(desired, deployment)
+		if err != nil {
+			return err
+		}
+	}
+	if specChanged || len(drifted) > 0 {
+		logger.V(4).Info("Update deployment resource", "currentReplicas", deployment.Spec.Replicas, "desiredReplicas", desired.Spec.Replicas, "specChanged", specChanged, "driftedFields", drifted)
+		if err := c.upgradeManagedFields(ctx, deployment); err != nil {
+			return err
+		}
+		live := deployment
+		deployment, err = c.applyDeployment(ctx, desired, len(drifted) > 0)
+		if err == nil {
+			c.recordDeploymentChange(foo, desired, live, deployment, drifted)
+		}
+	}
+
+	// Apply reports fields owned by another manager as a conflict. That is
+	// not going to go away by itself, so it is surfaced on the Foo as well.
+	if errors.IsConflict(err) {
+		msg := fmt.Sprintf(MessageFieldConflict, deploymentName, err)
+		if err := c.updateFooConflictStatus(ctx, foo, deployment, samplev1alpha1.FooFieldConflict, ErrFieldConflict, msg); err != nil {
+			return err
+		}
 	}
 
-	// If an error occurs during Update, we'll requeue the item so we can
+	// If an error occurs during Apply, we'll requeue the item so we can
 	// attempt processing again later. This could have been caused by a
 	// temporary network failure, or any other transient reason.
 	if err != nil {
 		return err
 	}
 
+	// Once the Deployment has rolled out, or failed to, the template is
+	// recorded as good or rolled back.
+	deployment, rolledBack, err = c.trackRollout(ctx, foo, desired, deployment, stableHash, rolledBack)
+	if err != nil {
+		return err
+	}
+
+	// The canary Deployment runs next to it for as long as the canary
+	// rollout does.
+	if err := c.syncCanaryDeployment(ctx, foo, canary, deployment); err != nil {
+		return err
+	}
+
+	// Deployments left behind by a change of spec.deploymentName are removed
+	// once their replacement is available.
+	if err := c.deleteStaleDeployments(ctx, foo, deployment); err != nil {
+		return err
+	}
+
+	// The Service is optional and selects the pods of the Deployment.
+	service, err := c.syncService(ctx, foo)
+	if err != nil {
+		return err
+	}
+
+	// So is the PodDisruptionBudget, which protects them from evictions.
+	pdb, err := c.syncDisruptionBudget(ctx, foo)
+	if err != nil {
+		return err
+	}
+
+	// And the HorizontalPodAutoscaler, which scales the Deployment.
+	hpa, err := c.syncAutoscaler(ctx, foo)
+	if err != nil {
+		return err
+	}
+
 	// Finally, we update the status block of the Foo resource to reflect the
 	// current state of the world
-	err = c.updateFooStatus(ctx, foo, deployment)
+	err = c.updateFooStatus(ctx, foo, deployment, service, pdb, hpa, metav1.Condition{
+		Type:   samplev1alpha1.FooMissingDependency,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonAsExpected,
+	}, rolledBackCondition(foo, rolledBack), suspended)
 	if err != nil {
 		return err
 	}
 
-	c.recorder.Event(foo, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	// A rollout that failed without being rolled back needs attention. It is
+	// recorded once, when the Foo becomes degraded.
+	if progressDeadlineExceeded(deployment) && !meta.IsStatusConditionTrue(foo.Status.Conditions, samplev1alpha1.FooDegraded) {
+		cond := getDeploymentCondition(deployment.Status, appsv1.DeploymentProgressing)
+		c.recorder.Eventf(foo, deployment, corev1.EventTypeWarning, ErrRolloutFailed, ActionRollout, MessageRolloutFailed, deployment.Annotations[deploymentRevisionAnnotation], deployment.Name, cond.Message)
+	}
 	return nil
 }
 
-func (c *Controller) updateFooStatus(ctx context.Context, foo *samplev1alpha1.Foo, deployment *appsv1.Deployment) error {
+// updateFooStatus reports the state of deployment, service, pdb and hpa on
+// foo, along with the given conditions.
+func (c *Controller) updateFooStatus(ctx context.Context, foo *samplev1alpha1.Foo, deployment *appsv1.Deployment, service *corev1.Service, pdb *policyv1.PodDisruptionBudget, hpa *autoscalingv2.HorizontalPodAutoscaler, conditions ...metav1.Condition) error {
 	// NEVER modify objects from the store. It's a read-only, local cache.
 	// You can use DeepCopy() to make a deep copy of original object and modify this copy
 	// Or create a copy manually for better performance
 	fooCopy := foo.DeepCopy()
+	fooCopy.Status.DeploymentName = deployment.Name
+	fooCopy.Status.Replicas = deployment.Status.Replicas
+	fooCopy.Status.Selector = metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: selectorLabels(foo)})
 	fooCopy.Status.AvailableReplicas = deployment.Status.AvailableReplicas
-	// If the CustomResourceSubresources feature gate is not enabled,
-	// we must use Update instead of UpdateStatus to update the Status block of the Foo resource.
-	// UpdateStatus will not allow changes to the Spec of the resource,
-	// which is ideal for ensuring nothing other than resource status has been updated.
-	_, err := c.sampleclientset.SamplecontrollerV1alpha1().Foos(foo.Namespace).UpdateStatus(ctx, fooCopy, metav1.UpdateOptions{FieldManager: FieldManager})
+	fooCopy.Status.ReadyReplicas = deployment.Status.ReadyReplicas
+	fooCopy.Status.UpdatedReplicas = deployment.Status.UpdatedReplicas
+	fooCopy.Status.Service = serviceStatus(service)
+	fooCopy.Status.DisruptionBudget = disruptionBudgetStatus(pdb)
+	fooCopy.Status.Autoscaling = autoscalerStatus(hpa)
+	c.setFooConditions(fooCopy, append(deploymentConditions(deployment), conditions...)...)
+	return c.writeFooStatus(ctx, foo, fooCopy)
+}
+
+// updateFooConflictStatus records on the Foo that its Deployment could not be
+// written, setting the given conflict condition and marking it not ready. A
+// Warning Event about the conflict, related to the object in the way if any,
+// is emitted when the condition is set, rather than on every retry.
+func (c *Controller) updateFooConflictStatus(ctx context.Context, foo *samplev1alpha1.Foo, related runtime.Object, conditionType, reason, msg string) error {
+	if cond := meta.FindStatusCondition(foo.Status.Conditions, conditionType); cond == nil || cond.Status != metav1.ConditionTrue || cond.Message != msg {
+		c.recorder.Eventf(foo, related, corev1.EventTypeWarning, reason, ActionSync, "%s", msg)
+	}
+	fooCopy := foo.DeepCopy()
+	c.setFooConditions(fooCopy,
+		metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: msg,
+		},
+		metav1.Condition{
+			Type:    samplev1alpha1.FooReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: msg,
+		},
+		c.fooSuspendedCondition(foo),
+	)
+	return c.writeFooStatus(ctx, foo, fooCopy)
+}
+
+// recordDeploymentChange emits an Event for the change applied to the live
+// Deployment of foo from desired: the drifted fields that were reverted, or
+// else the replicas and the other fields of the Foo that changed.
+func (c *Controller) recordDeploymentChange(foo *samplev1alpha1.Foo, desired, live, deployment *appsv1.Deployment, drifted []string) {
+	if len(drifted) > 0 {
+		c.recorder.Eventf(foo, deployment, corev1.EventTypeNormal, DriftCorrected, ActionUpdate, MessageDriftCorrected, deployment.Name, strings.Join(drifted, ", "))
+		return
+	}
+	changed, err := deploymentDrift(desired, live)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	var updated []string
+	for _, path := range changed {
+		switch path {
+		case "spec.replicas":
+			c.recorder.Eventf(foo, deployment, corev1.EventTypeNormal, DeploymentScaled, ActionScale, MessageDeploymentScaled, deployment.Name, desiredReplicas(live), desiredReplicas(deployment))
+		case "metadata.annotations." + DesiredStateHashAnnotation:
+		default:
+			updated = append(updated, path)
+		}
+	}
+	if len(updated) > 0 {
+		c.recorder.Eventf(foo, deployment, corev1.EventTypeNormal, DeploymentUpdated, ActionUpdate, MessageDeploymentUpdated, strings.Join(updated, ", "), deployment.Name)
+	}
+}
+
+// setFooConditions stamps the generation on foo's status and merges
+// conditions into it. Transition times are only moved when a condition
+// actually changes status.
+func (c *Controller) setFooConditions(foo *samplev1alpha1.Foo, conditions ...metav1.Condition) {
+	now := metav1.NewTime(c.clock.Now())
+	foo.Status.ObservedGeneration = foo.Generation
+	for _, condition := range conditions {
+		condition.ObservedGeneration = foo.Generation
+		condition.LastTransitionTime = now
+		meta.SetStatusCondition(&foo.Status.Conditions, condition)
+	}
+}
+
+// writeFooStatus applies the status of foo, updated from that of original,
+// stamping the sync time. Nothing is written if the status did not change,
+// as every write updates the Foo and has it synced again. Only the status
+// subresource is written, and only the fields set in it are claimed by
+// FieldManager.
+func (c *Controller) writeFooStatus(ctx context.Context, original, foo *samplev1alpha1.Foo) error {
+	if equality.Semantic.DeepEqual(original.Status, foo.Status) {
+		return nil
+	}
+	now := metav1.NewTime(c.clock.Now())
+	foo.Status.LastSyncTime = &now
+	fooApplyConfig, err := newFooStatusApplyConfiguration(foo)
+	if err != nil {
+		return err
+	}
+	_, err = c.sampleclientset.SamplecontrollerV1alpha1().Foos(foo.Namespace).ApplyStatus(ctx, fooApplyConfig, metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
 	return err
 }
 
+// applyDeployment server-side applies the desired Deployment built by
+// newDeployment.
+func (c *Controller) applyDeployment(ctx context.Context, desired *appsv1.Deployment, force bool) (*appsv1.Deployment, error) {
+	deploymentApplyConfig, err := newDeploymentApplyConfiguration(desired)
+	if err != nil {
+		return nil, err
+	}
+	return c.kubeclientset.AppsV1().Deployments(desired.Namespace).Apply(ctx, deploymentApplyConfig, metav1.ApplyOptions{FieldManager: FieldManager, Force: force})
+}
+
+// deploymentConditions derives the Ready, Progressing, Degraded and
+// ResourceConflict conditions of a Foo from the status of the Deployment it
+// controls.
+func deploymentConditions(deployment *appsv1.Deployment) []metav1.Condition {
+	desired := desiredReplicas(deployment)
+	status := deployment.Status
+	rolledOut := deploymentRolledOut(deployment)
+
+	degraded := metav1.Condition{
+		Type:   samplev1alpha1.FooDegraded,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonAsExpected,
+	}
+	if cond := getDeploymentCondition(status, appsv1.DeploymentProgressing); cond != nil && cond.Status == corev1.ConditionFalse {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = cond.Reason
+		degraded.Message = cond.Message
+	} else if cond := getDeploymentCondition(status, appsv1.DeploymentReplicaFailure); cond != nil && cond.Status == corev1.ConditionTrue {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = cond.Reason
+		degraded.Message = cond.Message
+	}
+
+	progressing := metav1.Condition{
+		Type:    samplev1alpha1.FooProgressing,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonRolloutComplete,
+		Message: fmt.Sprintf("Deployment %q has successfully progressed", deployment.Name),
+	}
+	if degraded.Status == metav1.ConditionTrue {
+		progressing.Reason = degraded.Reason
+		progressing.Message = degraded.Message
+	} else if !rolledOut {
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = ReasonRollingOut
+		progressing.Message = fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, desired)
+	}
+
+	ready := metav1.Condition{
+		Type:    samplev1alpha1.FooReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonRolloutComplete,
+		Message: fmt.Sprintf("%d of %d replicas are available", status.AvailableReplicas, desired),
+	}
+	if !rolledOut {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = ReasonReplicasUnavailable
+	}
+
+	conflict := metav1.Condition{
+		Type:   samplev1alpha1.FooResourceConflict,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonDeploymentControlled,
+	}
+
+	fieldConflict := metav1.Condition{
+		Type:   samplev1alpha1.FooFieldConflict,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonAsExpected,
+	}
+
+	return []metav1.Condition{ready, progressing, degraded, conflict, fieldConflict}
+}
+
+// desiredReplicas returns the number of replicas the Deployment asks for,
+// applying the API default of 1.
+func desiredReplicas(deployment *appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas != nil {
+		return *deployment.Spec.Replicas
+	}
+	return 1
+}
+
+// deploymentRolledOut reports whether every replica of the Deployment runs
+// its current template and is available.
+func deploymentRolledOut(deployment *appsv1.Deployment) bool {
+	desired := desiredReplicas(deployment)
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == desired &&
+		status.AvailableReplicas == desired &&
+		status.Replicas == desired
+}
+
+// getDeploymentCondition returns the condition of the given type, or nil if
+// the Deployment does not report it.
+func getDeploymentCondition(status appsv1.DeploymentStatus, condType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 // enqueueFoo takes a Foo resource and converts it into a namespace/name
 // string which is then put onto the work queue. This method should *not* be
 // passed resources of any type other than Foo.
+// Foos of namespaces whose informers have not synced yet are skipped; they
+// are all queued once they have.
 func (c *Controller) enqueueFoo(obj interface{}) {
-	if objectRef, err := cache.ObjectToName(obj); err != nil {
+	if objectRef, err := cache.DeletionHandlingObjectToName(obj); err != nil {
 		utilruntime.HandleError(err)
 		return
-	} else {
+	} else if c.namespaceSynced(objectRef.Namespace) {
 		c.workqueue.Add(objectRef)
 	}
 }
 
+// fooChanged reports whether the update from old to new needs the Foo synced:
+// a resync, a change of its spec, labels or annotations, or its deletion.
+// Updates of the status alone do not.
+func fooChanged(old, new *samplev1alpha1.Foo) bool {
+	return old.ResourceVersion == new.ResourceVersion ||
+		old.Generation != new.Generation ||
+		!equality.Semantic.DeepEqual(old.Labels, new.Labels) ||
+		!equality.Semantic.DeepEqual(old.Annotations, new.Annotations) ||
+		!equality.Semantic.DeepEqual(old.DeletionTimestamp, new.DeletionTimestamp)
+}
+
 // handleObject will take any resource implementing metav1.Object and attempt
 // to find the Foo resource that 'owns' it. It does this by looking at the
 // objects metadata.ownerReferences field for an appropriate OwnerReference.
@@ -417,37 +1070,134 @@ func (c *Controller) handleObject(obj interface{}) {
 // newDeployment creates a new Deployment for a Foo resource. It also sets
 // the appropriate OwnerReferences on the resource so handleObject can discover
 // the Foo resource that 'owns' it.
-func newDeployment(foo *samplev1alpha1.Foo) *appsv1.Deployment {
-	labels := map[string]string{
-		"app":        "nginx",
-		"controller": foo.Name,
+//
+// The pod template is taken from foo.Spec.Template, with the controller's own
+// selector labels merged over any labels the user supplied. A Foo without any
+// containers in its template falls back to a single nginx container.
+// configHash, if not empty, is recorded in the pod template so that pods are
+// replaced when the ConfigMaps and Secrets they use change.
+func newDeployment(foo *samplev1alpha1.Foo, configHash string) *appsv1.Deployment {
+	labels := selectorLabels(foo)
+
+	template := foo.Spec.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		template.Labels[k] = v
+	}
+	if configHash != "" {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[ConfigHashAnnotation] = configHash
+	}
+	if len(template.Spec.Containers) == 0 {
+		template.Spec.Containers = []corev1.Container{
+			{
+				Name:  "nginx",
+				Image: "nginx:latest",
+			},
+		}
 	}
+
+	spec := appsv1.DeploymentSpec{
+		Replicas: foo.Spec.Replicas,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		Template: *template,
+	}
+
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      foo.Spec.DeploymentName,
 			Namespace: foo.Namespace,
+			Annotations: map[string]string{
+				DesiredStateHashAnnotation: desiredStateHash(foo, spec),
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(foo, samplev1alpha1.SchemeGroupVersion.WithKind("Foo")),
 			},
 		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: foo.Spec.Replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: labels,
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:  "nginx",
-							Image: "nginx:latest",
-						},
-					},
-				},
-			},
-		},
+		Spec: spec,
+	}
+}
+
+// desiredStateHash returns the hash recorded in the DesiredStateHashAnnotation
+// of the Deployment of foo with the given spec. The replicas of an autoscaled
+// Foo only seed a new Deployment, so changing them is not a change of the
+// desired state.
+func desiredStateHash(foo *samplev1alpha1.Foo, spec appsv1.DeploymentSpec) string {
+	if foo.Spec.Autoscaling != nil {
+		spec.Replicas = nil
+	}
+	return computeHash(spec)
+}
+
+// upgradeManagedFields hands the fields of deployment that earlier versions of
+// this controller owned through Create and Update over to its Apply field
+// manager, so that applying does not conflict with the controller's own
+// earlier writes.
+func (c *Controller) upgradeManagedFields(ctx context.Context, deployment *appsv1.Deployment) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(deployment, sets.New(FieldManager), FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	_, err = c.kubeclientset.AppsV1().Deployments(deployment.Namespace).Patch(ctx, deployment.Name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// newDeploymentApplyConfiguration turns the desired Deployment built by
+// newDeployment into an apply configuration. Only the fields the controller
+// owns are set: the annotations and owner references it adds, replicas (when
+// the Foo specifies them), the selector and the pod template.
+func newDeploymentApplyConfiguration(deployment *appsv1.Deployment) (*appsv1ac.DeploymentApplyConfiguration, error) {
+	template := &corev1ac.PodTemplateSpecApplyConfiguration{}
+	if err := convert.ViaJSON(&deployment.Spec.Template, template); err != nil {
+		return nil, err
 	}
+	spec := appsv1ac.DeploymentSpec().
+		WithSelector(metav1ac.LabelSelector().WithMatchLabels(deployment.Spec.Selector.MatchLabels)).
+		WithTemplate(template)
+	if deployment.Spec.Replicas != nil {
+		spec.WithReplicas(*deployment.Spec.Replicas)
+	}
+
+	applyConfig := appsv1ac.Deployment(deployment.Name, deployment.Namespace).
+		WithAnnotations(deployment.Annotations).
+		WithSpec(spec)
+	for _, ref := range deployment.OwnerReferences {
+		applyConfig.WithOwnerReferences(ownerReference(ref))
+	}
+	return applyConfig, nil
+}
+
+// newFooStatusApplyConfiguration returns an apply configuration holding the
+// status of foo.
+func newFooStatusApplyConfiguration(foo *samplev1alpha1.Foo) (*samplev1alpha1ac.FooApplyConfiguration, error) {
+	status := &samplev1alpha1ac.FooStatusApplyConfiguration{}
+	if err := convert.ViaJSON(&foo.Status, status); err != nil {
+		return nil, err
+	}
+	return samplev1alpha1ac.Foo(foo.Name, foo.Namespace).WithStatus(status), nil
+}
+
+// selectorLabels returns the labels the controller uses to select the pods
+// belonging to a Foo. They always take precedence over user supplied labels.
+// The selector of a Deployment cannot be changed, so it keeps the app label
+// that the Deployments created by earlier versions of the controller select.
+func selectorLabels(foo *samplev1alpha1.Foo) map[string]string {
+	return map[string]string{
+		"app":        "nginx",
+		"controller": foo.Name,
+	}
+}
+
+// computeHash returns a stable, short hash of obj suitable for use in an
+// annotation value.
+func computeHash(obj interface{}) string {
+	hasher := fnv.New32a()
+	fmt.Fprint(hasher, dump.ForHash(obj))
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
 }
@@ -18,31 +18,44 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	apps "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apimachinery/pkg/watch"
 	kubeinformers "k8s.io/client-go/informers"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2/ktesting"
+	testingclock "k8s.io/utils/clock/testing"
 
 	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
 	"k8s.io/sample-controller/pkg/generated/clientset/versioned/fake"
+	samplescheme "k8s.io/sample-controller/pkg/generated/clientset/versioned/scheme"
 	informers "k8s.io/sample-controller/pkg/generated/informers/externalversions"
 )
 
 var (
 	alwaysReady        = func() bool { return true }
 	noResyncPeriodFunc = func() time.Duration { return 0 }
+	syncTime           = metav1.NewTime(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC))
 )
 
 type fixture struct {
@@ -53,12 +66,24 @@ type fixture struct {
 	// Objects to put in the store.
 	fooLister        []*samplecontroller.Foo
 	deploymentLister []*apps.Deployment
+	serviceLister    []*corev1.Service
+	pdbLister        []*policyv1.PodDisruptionBudget
+	hpaLister        []*autoscalingv2.HorizontalPodAutoscaler
+	configMapLister  []*corev1.ConfigMap
+	secretLister     []*corev1.Secret
 	// Actions expected to happen on the client.
 	kubeactions []core.Action
 	actions     []core.Action
-	// Objects from here preloaded into NewSimpleFake.
+	// Objects from here preloaded into NewSimpleFake. Deployments are
+	// server-side applied by the controller's field manager, as if the
+	// controller had created them.
 	kubeobjects []runtime.Object
 	objects     []runtime.Object
+	// Objects preloaded as-is, as if written by a client that does not use
+	// server-side apply.
+	foreignKubeobjects []runtime.Object
+	// fooSelector is the label selector of the Foos reconciled.
+	fooSelector string
 }
 
 func newFixture(t *testing.T) *fixture {
@@ -69,33 +94,82 @@ func newFixture(t *testing.T) *fixture {
 	return f
 }
 
+// newFooClientset returns a fake clientset that supports server-side apply.
+// The generated apply configurations carry no OpenAPI schema for Foo, so the
+// object tracker deduces the structure of Foo objects instead.
+func newFooClientset(objects ...runtime.Object) *fake.Clientset {
+	o := core.NewFieldManagedObjectTracker(samplescheme.Scheme, samplescheme.Codecs.UniversalDecoder(), managedfields.NewDeducedTypeConverter())
+	for _, obj := range objects {
+		if err := o.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	cs := &fake.Clientset{}
+	cs.AddReactor("*", "*", core.ObjectReaction(o))
+	cs.AddWatchReactor("*", func(action core.Action) (handled bool, ret watch.Interface, err error) {
+		var opts metav1.ListOptions
+		if watchAction, ok := action.(core.WatchActionImpl); ok {
+			opts = watchAction.ListOptions
+		}
+		w, err := o.Watch(action.GetResource(), action.GetNamespace(), opts)
+		if err != nil {
+			return false, nil, err
+		}
+		return true, w, nil
+	})
+	return cs
+}
+
+// newFoo returns a Foo as the defaulting webhook stores it.
 func newFoo(name string, replicas *int32) *samplecontroller.Foo {
-	return &samplecontroller.Foo{
+	foo := &samplecontroller.Foo{
 		TypeMeta: metav1.TypeMeta{APIVersion: samplecontroller.SchemeGroupVersion.String()},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: metav1.NamespaceDefault,
+			Name:       name,
+			Namespace:  metav1.NamespaceDefault,
+			Finalizers: []string{FooFinalizer},
 		},
 		Spec: samplecontroller.FooSpec{
 			DeploymentName: fmt.Sprintf("%s-deployment", name),
 			Replicas:       replicas,
 		},
 	}
+	samplescheme.Scheme.Default(foo)
+	return foo
 }
 
 func (f *fixture) newController(ctx context.Context) (*Controller, informers.SharedInformerFactory, kubeinformers.SharedInformerFactory) {
-	f.client = fake.NewSimpleClientset(f.objects...)
-	f.kubeclient = k8sfake.NewSimpleClientset(f.kubeobjects...)
+	f.client = newFooClientset(f.objects...)
+	f.kubeclient = k8sfake.NewClientset(f.foreignKubeobjects...)
+	for _, obj := range f.kubeobjects {
+		if err := f.seedKubeobject(ctx, obj); err != nil {
+			f.t.Fatalf("error seeding %T: %v", obj, err)
+		}
+	}
+	f.kubeclient.ClearActions()
 
-	i := informers.NewSharedInformerFactory(f.client, noResyncPeriodFunc())
-	k8sI := kubeinformers.NewSharedInformerFactory(f.kubeclient, noResyncPeriodFunc())
+	scope := newInformerScope(f.kubeclient, f.client, noResyncPeriodFunc())
+	scope.addNamespace(metav1.NamespaceAll)
+	i := scope.namespaces[metav1.NamespaceAll].sample
+	k8sI := scope.namespaces[metav1.NamespaceAll].kube
 
-	c := NewController(ctx, f.kubeclient, f.client,
-		k8sI.Apps().V1().Deployments(), i.Samplecontroller().V1alpha1().Foos())
+	cfg, err := defaultConfiguration()
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	cfg.FooSelector = f.fooSelector
+	c := NewController(ctx, f.kubeclient, f.client, scope, cfg)
 
 	c.foosSynced = alwaysReady
 	c.deploymentsSynced = alwaysReady
-	c.recorder = &record.FakeRecorder{}
+	c.servicesSynced = alwaysReady
+	c.pdbsSynced = alwaysReady
+	c.hpasSynced = alwaysReady
+	c.configMapsSynced = alwaysReady
+	c.secretsSynced = alwaysReady
+	c.recorder = &events.FakeRecorder{}
+	c.clock = testingclock.NewFakePassiveClock(syncTime.Time)
 
 	for _, f := range f.fooLister {
 		i.Samplecontroller().V1alpha1().Foos().Informer().GetIndexer().Add(f)
@@ -105,9 +179,49 @@ func (f *fixture) newController(ctx context.Context) (*Controller, informers.Sha
 		k8sI.Apps().V1().Deployments().Informer().GetIndexer().Add(d)
 	}
 
+	for _, s := range f.serviceLister {
+		k8sI.Core().V1().Services().Informer().GetIndexer().Add(s)
+	}
+
+	for _, pdb := range f.pdbLister {
+		k8sI.Policy().V1().PodDisruptionBudgets().Informer().GetIndexer().Add(pdb)
+	}
+
+	for _, hpa := range f.hpaLister {
+		k8sI.Autoscaling().V2().HorizontalPodAutoscalers().Informer().GetIndexer().Add(hpa)
+	}
+
+	for _, cm := range f.configMapLister {
+		k8sI.Core().V1().ConfigMaps().Informer().GetIndexer().Add(cm)
+	}
+
+	for _, s := range f.secretLister {
+		k8sI.Core().V1().Secrets().Informer().GetIndexer().Add(s)
+	}
+
 	return c, i, k8sI
 }
 
+func (f *fixture) seedKubeobject(ctx context.Context, obj runtime.Object) error {
+	d, ok := obj.(*apps.Deployment)
+	if !ok {
+		return f.kubeclient.Tracker().Add(obj)
+	}
+	applyConfig, err := newDeploymentApplyConfiguration(d)
+	if err != nil {
+		return err
+	}
+	stored, err := f.kubeclient.AppsV1().Deployments(d.Namespace).Apply(ctx, applyConfig, metav1.ApplyOptions{FieldManager: FieldManager})
+	if err != nil {
+		return err
+	}
+	// The informers replace the Deployments of the listers with those of the
+	// clientset once they have listed them, which must not lose the status.
+	stored.Status = d.Status
+	_, err = f.kubeclient.AppsV1().Deployments(d.Namespace).UpdateStatus(ctx, stored, metav1.UpdateOptions{})
+	return err
+}
+
 func (f *fixture) run(ctx context.Context, fooRef cache.ObjectName) {
 	f.runController(ctx, fooRef, true, false)
 }
@@ -193,6 +307,13 @@ func checkAction(expected, actual core.Action, t *testing.T) {
 			t.Errorf("Action %s %s has wrong object\nDiff:\n %s",
 				a.GetVerb(), a.GetResource().Resource, diff.ObjectGoPrintSideBySide(expObject, object))
 		}
+	case core.DeleteActionImpl:
+		e, _ := expected.(core.DeleteActionImpl)
+
+		if e.GetName() != a.GetName() || e.GetNamespace() != a.GetNamespace() {
+			t.Errorf("Action %s %s has wrong object\nExpected %s/%s, got %s/%s",
+				a.GetVerb(), a.GetResource().Resource, e.GetNamespace(), e.GetName(), a.GetNamespace(), a.GetName())
+		}
 	case core.PatchActionImpl:
 		e, _ := expected.(core.PatchActionImpl)
 		expPatch := e.GetPatch()
@@ -218,7 +339,17 @@ func filterInformerActions(actions []core.Action) []core.Action {
 			(action.Matches("list", "foos") ||
 				action.Matches("watch", "foos") ||
 				action.Matches("list", "deployments") ||
-				action.Matches("watch", "deployments")) {
+				action.Matches("watch", "deployments") ||
+				action.Matches("list", "services") ||
+				action.Matches("watch", "services") ||
+				action.Matches("list", "poddisruptionbudgets") ||
+				action.Matches("watch", "poddisruptionbudgets") ||
+				action.Matches("list", "horizontalpodautoscalers") ||
+				action.Matches("watch", "horizontalpodautoscalers") ||
+				action.Matches("list", "configmaps") ||
+				action.Matches("watch", "configmaps") ||
+				action.Matches("list", "secrets") ||
+				action.Matches("watch", "secrets")) {
 			continue
 		}
 		ret = append(ret, action)
@@ -227,16 +358,40 @@ func filterInformerActions(actions []core.Action) []core.Action {
 	return ret
 }
 
-func (f *fixture) expectCreateDeploymentAction(d *apps.Deployment) {
-	f.kubeactions = append(f.kubeactions, core.NewCreateAction(schema.GroupVersionResource{Resource: "deployments"}, d.Namespace, d))
+func (f *fixture) expectApplyDeploymentAction(d *apps.Deployment) {
+	applyConfig, err := newDeploymentApplyConfiguration(d)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	patch, err := json.Marshal(applyConfig)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.kubeactions = append(f.kubeactions, core.NewPatchAction(schema.GroupVersionResource{Resource: "deployments"}, d.Namespace, d.Name, types.ApplyPatchType, patch))
 }
 
 func (f *fixture) expectUpdateDeploymentAction(d *apps.Deployment) {
 	f.kubeactions = append(f.kubeactions, core.NewUpdateAction(schema.GroupVersionResource{Resource: "deployments"}, d.Namespace, d))
 }
 
-func (f *fixture) expectUpdateFooStatusAction(foo *samplecontroller.Foo) {
-	action := core.NewUpdateSubresourceAction(schema.GroupVersionResource{Resource: "foos"}, "status", foo.Namespace, foo)
+func (f *fixture) expectDeleteDeploymentAction(d *apps.Deployment) {
+	f.kubeactions = append(f.kubeactions, core.NewDeleteAction(schema.GroupVersionResource{Resource: "deployments"}, d.Namespace, d.Name))
+}
+
+func (f *fixture) expectUpdateFooAction(foo *samplecontroller.Foo) {
+	f.actions = append(f.actions, core.NewUpdateAction(schema.GroupVersionResource{Resource: "foos"}, foo.Namespace, foo))
+}
+
+func (f *fixture) expectApplyFooStatusAction(foo *samplecontroller.Foo) {
+	applyConfig, err := newFooStatusApplyConfiguration(foo)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	patch, err := json.Marshal(applyConfig)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	action := core.NewPatchSubresourceAction(schema.GroupVersionResource{Resource: "foos"}, foo.Namespace, foo.Name, types.ApplyPatchType, patch, "status")
 	f.actions = append(f.actions, action)
 }
 
@@ -253,9 +408,74 @@ func TestCreatesDeployment(t *testing.T) {
 	f.fooLister = append(f.fooLister, foo)
 	f.objects = append(f.objects, foo)
 
-	expDeployment := newDeployment(foo)
-	f.expectCreateDeploymentAction(expDeployment)
-	f.expectUpdateFooStatusAction(foo)
+	expDeployment := newDeployment(foo, "")
+	f.expectApplyDeploymentAction(expDeployment)
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestDefaultsFooFromLister(t *testing.T) {
+	f := newFixture(t)
+	// A Foo stored before the defaulting webhook was installed.
+	foo := newFoo("test", nil)
+	foo.Spec = samplecontroller.FooSpec{}
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	defaulted := newFoo("test", int32Ptr(1))
+	defaulted.Spec.DeploymentName = "test"
+	expDeployment := newDeployment(defaulted, "")
+	// Labeling the pods would roll them out, so that is left to the webhook
+	// for new Foos.
+	if _, ok := expDeployment.Spec.Template.Labels[samplecontroller.NameLabel]; ok {
+		t.Fatalf("expected the pod template not to be labeled, got %v", expDeployment.Spec.Template.Labels)
+	}
+	f.expectApplyDeploymentAction(expDeployment)
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(defaulted)))
+
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestAddsFinalizer(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	foo.Finalizers = nil
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	expFoo := foo.DeepCopy()
+	expFoo.Finalizers = []string{FooFinalizer}
+	f.expectUpdateFooAction(expFoo)
+	f.expectApplyDeploymentAction(newDeployment(foo, ""))
+	f.expectApplyFooStatusAction(withStatus(expFoo, rollingOutStatus(foo)))
+
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestAddsFinalizerWithoutDefaults(t *testing.T) {
+	f := newFixture(t)
+	// A Foo stored before the defaulting webhook was installed.
+	foo := newFoo("test", nil)
+	foo.Finalizers = nil
+	foo.Spec = samplecontroller.FooSpec{}
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	// Only the finalizer is added, the defaults are not stored.
+	expFoo := foo.DeepCopy()
+	expFoo.Finalizers = []string{FooFinalizer}
+	f.expectUpdateFooAction(expFoo)
+	defaulted := newFoo("test", int32Ptr(1))
+	defaulted.Spec.DeploymentName = "test"
+	f.expectApplyDeploymentAction(newDeployment(defaulted, ""))
+	f.expectApplyFooStatusAction(withStatus(expFoo, rollingOutStatus(defaulted)))
 
 	f.run(ctx, getRef(foo, t))
 }
@@ -265,14 +485,14 @@ func TestDoNothing(t *testing.T) {
 	foo := newFoo("test", int32Ptr(1))
 	_, ctx := ktesting.NewTestContext(t)
 
-	d := newDeployment(foo)
+	d := newDeployment(foo, "")
 
 	f.fooLister = append(f.fooLister, foo)
 	f.objects = append(f.objects, foo)
 	f.deploymentLister = append(f.deploymentLister, d)
 	f.kubeobjects = append(f.kubeobjects, d)
 
-	f.expectUpdateFooStatusAction(foo)
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
 	f.run(ctx, getRef(foo, t))
 }
 
@@ -281,28 +501,299 @@ func TestUpdateDeployment(t *testing.T) {
 	foo := newFoo("test", int32Ptr(1))
 	_, ctx := ktesting.NewTestContext(t)
 
-	d := newDeployment(foo)
+	d := newDeployment(foo, "")
 
 	// Update replicas
 	foo.Spec.Replicas = int32Ptr(2)
-	expDeployment := newDeployment(foo)
+	expDeployment := newDeployment(foo, "")
 
 	f.fooLister = append(f.fooLister, foo)
 	f.objects = append(f.objects, foo)
 	f.deploymentLister = append(f.deploymentLister, d)
 	f.kubeobjects = append(f.kubeobjects, d)
 
-	f.expectUpdateFooStatusAction(foo)
-	f.expectUpdateDeploymentAction(expDeployment)
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+	f.expectApplyDeploymentAction(expDeployment)
 	f.run(ctx, getRef(foo, t))
 }
 
+func TestUpdateDeploymentTemplate(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+
+	// Update the pod template
+	foo.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:  "app",
+			Image: "example.com/app:v2",
+		},
+	}
+	expDeployment := newDeployment(foo, "")
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+	f.expectApplyDeploymentAction(expDeployment)
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestNewDeploymentMergesTemplateLabels(t *testing.T) {
+	foo := newFoo("test", int32Ptr(1))
+	foo.Spec.Template.Labels = map[string]string{
+		"tier":       "frontend",
+		"controller": "someone-else",
+	}
+
+	d := newDeployment(foo, "")
+
+	expLabels := map[string]string{
+		"tier":       "frontend",
+		"app":        "nginx",
+		"controller": "test",
+	}
+	if !reflect.DeepEqual(d.Spec.Template.Labels, expLabels) {
+		t.Errorf("unexpected template labels: %v", d.Spec.Template.Labels)
+	}
+	if !reflect.DeepEqual(d.Spec.Selector.MatchLabels, selectorLabels(foo)) {
+		t.Errorf("unexpected selector: %v", d.Spec.Selector.MatchLabels)
+	}
+	if foo.Spec.Template.Labels["controller"] != "someone-else" {
+		t.Errorf("newDeployment modified the Foo's template")
+	}
+}
+
+func TestUpgradesBaselineDeployment(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(2))
+	_, ctx := ktesting.NewTestContext(t)
+
+	// The first version of the controller created the Deployment without
+	// server-side apply, selecting its pods by these labels.
+	labels := map[string]string{"app": "nginx", "controller": foo.Name}
+	d := &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            foo.Spec.DeploymentName,
+			Namespace:       foo.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(foo, samplecontroller.SchemeGroupVersion.WithKind("Foo"))},
+		},
+		Spec: apps.DeploymentSpec{
+			Replicas: int32Ptr(1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:latest"}},
+				},
+			},
+		},
+	}
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	c, i, k8sI := f.newController(ctx)
+	d, err := f.kubeclient.AppsV1().Deployments(d.Namespace).Create(ctx, d, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k8sI.Apps().V1().Deployments().Informer().GetIndexer().Add(d); err != nil {
+		t.Fatal(err)
+	}
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := f.kubeclient.AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(updated.Spec.Selector.MatchLabels, labels) {
+		t.Errorf("expected the immutable selector %v to be kept, got %v", labels, updated.Spec.Selector.MatchLabels)
+	}
+	if !reflect.DeepEqual(updated.Spec.Template.Labels, labels) {
+		t.Errorf("expected the pods to keep the labels %v, so they are not rolled out, got %v", labels, updated.Spec.Template.Labels)
+	}
+	if *updated.Spec.Replicas != 2 {
+		t.Errorf("expected the Deployment to be scaled to 2, got %d", *updated.Spec.Replicas)
+	}
+}
+
+func TestApplyConflict(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	// The Deployment was last written by someone else, who now owns all of
+	// its fields.
+	d := newDeployment(foo, "")
+	foo.Spec.Replicas = int32Ptr(2)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.foreignKubeobjects = append(f.foreignKubeobjects, d)
+
+	c, i, k8sI := f.newController(ctx)
+	recorder := events.NewFakeRecorder(1)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, t)); !errors.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+
+	updated, err := f.client.SamplecontrollerV1alpha1().Foos(foo.Namespace).Get(ctx, foo.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, samplecontroller.FooFieldConflict)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ErrFieldConflict {
+		t.Errorf("expected %s condition to be set, got %+v", samplecontroller.FooFieldConflict, cond)
+	}
+	if !meta.IsStatusConditionFalse(updated.Status.Conditions, samplecontroller.FooReady) {
+		t.Errorf("expected Foo not to be ready, got %+v", updated.Status.Conditions)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, ErrFieldConflict) {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event")
+	}
+}
+
+func TestCorrectsDrift(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	edited := d.DeepCopy()
+	edited.Spec.Template.Spec.Containers[0].Image = "nginx:edited"
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, edited)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	c, i, k8sI := f.newController(ctx)
+
+	// Someone edited the image of the Deployment behind the Foo's back,
+	// taking ownership of the field.
+	live, err := f.kubeclient.AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	live.Spec.Template.Spec.Containers[0].Image = "nginx:edited"
+	if _, err := f.kubeclient.AppsV1().Deployments(d.Namespace).Update(ctx, live, metav1.UpdateOptions{FieldManager: "kubectl-edit"}); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := events.NewFakeRecorder(2)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatalf("error syncing foo: %v", err)
+	}
+
+	updated, err := f.kubeclient.AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := updated.Spec.Template.Spec.Containers[0].Image; image != "nginx:latest" {
+		t.Errorf("expected the image to be reverted, got %q", image)
+	}
+	expected := fmt.Sprintf(MessageDriftCorrected, d.Name, "spec.template.spec.containers[name=nginx].image")
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, DriftCorrected) || !strings.Contains(event, expected) {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event")
+	}
+}
+
+func TestDeploymentDrift(t *testing.T) {
+	foo := newFoo("test", int32Ptr(1))
+	desired := newDeployment(foo, "")
+
+	testCases := []struct {
+		name     string
+		mutate   func(d *apps.Deployment)
+		expected []string
+	}{
+		{
+			name:   "unchanged",
+			mutate: func(d *apps.Deployment) {},
+		},
+		{
+			name: "fields the controller does not set",
+			mutate: func(d *apps.Deployment) {
+				d.Annotations["deployment.kubernetes.io/revision"] = "2"
+				d.Spec.Template.Labels["injected"] = "true"
+				d.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+				d.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullAlways
+				d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, corev1.Container{Name: "sidecar", Image: "proxy"})
+				d.Spec.RevisionHistoryLimit = int32Ptr(10)
+			},
+		},
+		{
+			name: "image",
+			mutate: func(d *apps.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Image = "nginx:edited"
+			},
+			expected: []string{"spec.template.spec.containers[name=nginx].image"},
+		},
+		{
+			name: "replicas and labels",
+			mutate: func(d *apps.Deployment) {
+				d.Spec.Replicas = int32Ptr(3)
+				d.Spec.Template.Labels["controller"] = "other"
+			},
+			expected: []string{"spec.replicas", "spec.template.metadata.labels.controller"},
+		},
+		{
+			name: "removed container",
+			mutate: func(d *apps.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Name = "renamed"
+			},
+			expected: []string{"spec.template.spec.containers[name=nginx]"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			live := desired.DeepCopy()
+			tc.mutate(live)
+			drifted, err := deploymentDrift(desired, live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(drifted, tc.expected) {
+				t.Errorf("expected drift %v, got %v", tc.expected, drifted)
+			}
+		})
+	}
+}
+
 func TestNotControlledByUs(t *testing.T) {
 	f := newFixture(t)
 	foo := newFoo("test", int32Ptr(1))
 	_, ctx := ktesting.NewTestContext(t)
 
-	d := newDeployment(foo)
+	d := newDeployment(foo, "")
 
 	d.ObjectMeta.OwnerReferences = []metav1.OwnerReference{}
 
@@ -311,7 +802,329 @@ func TestNotControlledByUs(t *testing.T) {
 	f.deploymentLister = append(f.deploymentLister, d)
 	f.kubeobjects = append(f.kubeobjects, d)
 
+	msg := fmt.Sprintf(MessageResourceExists, d.Name)
+	f.expectApplyFooStatusAction(withStatus(foo, samplecontroller.FooStatus{
+		LastSyncTime: &syncTime,
+		Conditions: []metav1.Condition{
+			{Type: samplecontroller.FooResourceConflict, Status: metav1.ConditionTrue, Reason: ErrResourceExists, Message: msg, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooReady, Status: metav1.ConditionFalse, Reason: ErrResourceExists, Message: msg, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooSuspended, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+		},
+	}))
 	f.runExpectError(ctx, getRef(foo, t))
 }
 
+func newDeletingFoo(policy samplecontroller.DeletionPolicy) *samplecontroller.Foo {
+	foo := newFoo("test", int32Ptr(1))
+	now := metav1.Now()
+	foo.DeletionTimestamp = &now
+	foo.Spec.DeletionPolicy = policy
+	return foo
+}
+
+func withoutFinalizer(foo *samplecontroller.Foo) *samplecontroller.Foo {
+	foo = foo.DeepCopy()
+	foo.Finalizers = []string{}
+	return foo
+}
+
+func TestFinalizeDeletesDeployment(t *testing.T) {
+	for _, policy := range []samplecontroller.DeletionPolicy{"", samplecontroller.DeletionPolicyDelete} {
+		t.Run(string(policy), func(t *testing.T) {
+			f := newFixture(t)
+			foo := newDeletingFoo(policy)
+			_, ctx := ktesting.NewTestContext(t)
+
+			d := newDeployment(foo, "")
+
+			f.fooLister = append(f.fooLister, foo)
+			f.objects = append(f.objects, foo)
+			f.deploymentLister = append(f.deploymentLister, d)
+			f.kubeobjects = append(f.kubeobjects, d)
+
+			f.expectDeleteDeploymentAction(d)
+			// A Foo stored before defaulting was in place is written back
+			// with the defaults applied.
+			expFoo := withoutFinalizer(foo)
+			expFoo.Spec.DeletionPolicy = samplecontroller.DeletionPolicyDelete
+			f.expectUpdateFooAction(expFoo)
+			f.run(ctx, getRef(foo, t))
+		})
+	}
+}
+
+func TestFinalizeReleasesDeployment(t *testing.T) {
+	for _, policy := range []samplecontroller.DeletionPolicy{samplecontroller.DeletionPolicyOrphan, samplecontroller.DeletionPolicyRetain} {
+		t.Run(string(policy), func(t *testing.T) {
+			f := newFixture(t)
+			foo := newDeletingFoo(policy)
+			_, ctx := ktesting.NewTestContext(t)
+
+			d := newDeployment(foo, "")
+
+			f.fooLister = append(f.fooLister, foo)
+			f.objects = append(f.objects, foo)
+			f.deploymentLister = append(f.deploymentLister, d)
+			f.kubeobjects = append(f.kubeobjects, d)
+
+			expDeployment := d.DeepCopy()
+			expDeployment.OwnerReferences = []metav1.OwnerReference{}
+			if policy == samplecontroller.DeletionPolicyRetain {
+				expDeployment.Annotations[samplecontroller.RetainedFromAnnotation] = foo.Name
+			}
+			f.expectUpdateDeploymentAction(expDeployment)
+			f.expectUpdateFooAction(withoutFinalizer(foo))
+			f.run(ctx, getRef(foo, t))
+		})
+	}
+}
+
+func TestAdoptsRetainedDeployment(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.OwnerReferences = nil
+	d.Annotations[samplecontroller.RetainedFromAnnotation] = foo.Name
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	f.expectUpdateDeploymentAction(newDeployment(foo, ""))
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestStatusReady(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	foo.Generation = 2
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.Status = apps.DeploymentStatus{
+		Replicas:          1,
+		UpdatedReplicas:   1,
+		ReadyReplicas:     1,
+		AvailableReplicas: 1,
+	}
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	f.expectApplyFooStatusAction(withStatus(foo, readyStatus(foo)))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestSkipsUnchangedStatus(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.Status = availableStatus(1)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	c, i, k8sI := f.newController(ctx)
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatal(err)
+	}
+	if actions := filterInformerActions(f.client.Actions()); len(actions) != 1 {
+		t.Fatalf("expected the status to be applied once, got %+v", actions)
+	}
+
+	// The second sync finds the Foo with the status it just wrote.
+	updated, err := f.client.SamplecontrollerV1alpha1().Foos(foo.Namespace).Get(ctx, foo.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The object tracker does not bump the resourceVersion as the API
+	// server does.
+	updated.ResourceVersion = "2"
+	if fooChanged(foo, updated) {
+		t.Error("expected the status update not to need another sync")
+	}
+	if err := i.Samplecontroller().V1alpha1().Foos().Informer().GetIndexer().Update(updated); err != nil {
+		t.Fatal(err)
+	}
+	f.client.ClearActions()
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatal(err)
+	}
+	if actions := filterInformerActions(f.client.Actions()); len(actions) != 0 {
+		t.Errorf("expected no second status apply, got %+v", actions)
+	}
+}
+
+func TestFooChanged(t *testing.T) {
+	foo := newFoo("test", int32Ptr(1))
+	foo.ResourceVersion = "1"
+	for name, tc := range map[string]struct {
+		update   func(*samplecontroller.Foo)
+		expected bool
+	}{
+		"resync":     {func(*samplecontroller.Foo) {}, true},
+		"status":     {func(foo *samplecontroller.Foo) { foo.Status.ReadyReplicas = 1 }, false},
+		"generation": {func(foo *samplecontroller.Foo) { foo.Generation++ }, true},
+		"labels":     {func(foo *samplecontroller.Foo) { foo.Labels = map[string]string{"a": "b"} }, true},
+		"annotations": {func(foo *samplecontroller.Foo) {
+			foo.Annotations = map[string]string{"a": "b"}
+		}, true},
+		"deletion": {func(foo *samplecontroller.Foo) { foo.DeletionTimestamp = &syncTime }, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			updated := foo.DeepCopy()
+			tc.update(updated)
+			if name != "resync" {
+				updated.ResourceVersion = "2"
+			}
+			if got := fooChanged(foo, updated); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDeletesStaleDeployment(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	foo.Generation = 2
+	_, ctx := ktesting.NewTestContext(t)
+
+	stale := newDeployment(foo, "")
+	stale.Name = "test-old"
+	d := newDeployment(foo, "")
+	d.Status = apps.DeploymentStatus{
+		Replicas:          1,
+		UpdatedReplicas:   1,
+		ReadyReplicas:     1,
+		AvailableReplicas: 1,
+	}
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d, stale)
+	f.kubeobjects = append(f.kubeobjects, d, stale)
+
+	f.expectDeleteDeploymentAction(stale)
+	f.expectApplyFooStatusAction(withStatus(foo, readyStatus(foo)))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestKeepsStaleDeploymentWhileRollingOut(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	stale := newDeployment(foo, "")
+	stale.Name = "test-old"
+	d := newDeployment(foo, "")
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d, stale)
+	f.kubeobjects = append(f.kubeobjects, d, stale)
+
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestStatusDegraded(t *testing.T) {
+	foo := newFoo("test", int32Ptr(2))
+	d := newDeployment(foo, "")
+	d.Status = apps.DeploymentStatus{
+		Replicas:          2,
+		UpdatedReplicas:   1,
+		AvailableReplicas: 1,
+		Conditions: []apps.DeploymentCondition{
+			{Type: apps.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: "timed out"},
+		},
+	}
+
+	conditions := deploymentConditions(d)
+	for _, exp := range []metav1.Condition{
+		{Type: samplecontroller.FooReady, Status: metav1.ConditionFalse, Reason: ReasonReplicasUnavailable},
+		{Type: samplecontroller.FooProgressing, Status: metav1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+		{Type: samplecontroller.FooDegraded, Status: metav1.ConditionTrue, Reason: "ProgressDeadlineExceeded"},
+	} {
+		got := meta.FindStatusCondition(conditions, exp.Type)
+		if got == nil {
+			t.Errorf("missing condition %s", exp.Type)
+			continue
+		}
+		if got.Status != exp.Status || got.Reason != exp.Reason {
+			t.Errorf("condition %s: expected %s/%s, got %s/%s", exp.Type, exp.Status, exp.Reason, got.Status, got.Reason)
+		}
+	}
+}
+
+// rollingOutStatus is the status reported for foo right after its Deployment
+// has been created, before any of its replicas became available.
+func rollingOutStatus(foo *samplecontroller.Foo) samplecontroller.FooStatus {
+	return samplecontroller.FooStatus{
+		DeploymentName:     foo.Spec.DeploymentName,
+		Selector:           "app=nginx,controller=" + foo.Name,
+		ObservedGeneration: foo.Generation,
+		LastSyncTime:       &syncTime,
+		Conditions: []metav1.Condition{
+			{Type: samplecontroller.FooReady, Status: metav1.ConditionFalse, Reason: ReasonReplicasUnavailable, Message: fmt.Sprintf("0 of %d replicas are available", *foo.Spec.Replicas), LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooProgressing, Status: metav1.ConditionTrue, Reason: ReasonRollingOut, Message: fmt.Sprintf("0 of %d updated replicas are available", *foo.Spec.Replicas), LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooDegraded, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooResourceConflict, Status: metav1.ConditionFalse, Reason: ReasonDeploymentControlled, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooFieldConflict, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooMissingDependency, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooRolledBack, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooSuspended, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+		},
+	}
+}
+
+// readyStatus is the status reported for foo once all replicas of its
+// single-replica Deployment are available. Its template is recorded as the
+// last good one.
+func readyStatus(foo *samplecontroller.Foo) samplecontroller.FooStatus {
+	d := newDeployment(foo, "")
+	return samplecontroller.FooStatus{
+		DeploymentName:     foo.Spec.DeploymentName,
+		Replicas:           1,
+		Selector:           "app=nginx,controller=" + foo.Name,
+		AvailableReplicas:  1,
+		ReadyReplicas:      1,
+		UpdatedReplicas:    1,
+		ObservedGeneration: foo.Generation,
+		LastSyncTime:       &syncTime,
+		Conditions: []metav1.Condition{
+			{Type: samplecontroller.FooReady, Status: metav1.ConditionTrue, Reason: ReasonRolloutComplete, Message: "1 of 1 replicas are available", ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooProgressing, Status: metav1.ConditionFalse, Reason: ReasonRolloutComplete, Message: fmt.Sprintf("Deployment %q has successfully progressed", foo.Spec.DeploymentName), ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooDegraded, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooResourceConflict, Status: metav1.ConditionFalse, Reason: ReasonDeploymentControlled, ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooFieldConflict, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooMissingDependency, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooRolledBack, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooSuspended, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, ObservedGeneration: foo.Generation, LastTransitionTime: syncTime},
+		},
+		Rollout: &samplecontroller.FooRolloutStatus{
+			LastGoodTemplateHash: templateHash(d),
+			LastGoodTemplate:     &d.Spec.Template,
+		},
+	}
+}
+
+func withStatus(foo *samplecontroller.Foo, status samplecontroller.FooStatus) *samplecontroller.Foo {
+	foo = foo.DeepCopy()
+	foo.Status = status
+	return foo
+}
+
 func int32Ptr(i int32) *int32 { return &i }
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	"k8s.io/klog/v2"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+const (
+	// FooFinalizer is added to every Foo so the controller gets to apply the
+	// Foo's deletion policy to its children before the Foo goes away.
+	FooFinalizer = samplev1alpha1.FooFinalizer
+)
+
+func hasFinalizer(foo *samplev1alpha1.Foo) bool {
+	return slices.Contains(foo.Finalizers, FooFinalizer)
+}
+
+// addFinalizer adds FooFinalizer to foo and returns the updated object.
+func (c *Controller) addFinalizer(ctx context.Context, foo *samplev1alpha1.Foo) (*samplev1alpha1.Foo, error) {
+	fooCopy := foo.DeepCopy()
+	fooCopy.Finalizers = append(fooCopy.Finalizers, FooFinalizer)
+	return c.sampleclientset.SamplecontrollerV1alpha1().Foos(foo.Namespace).Update(ctx, fooCopy, metav1.UpdateOptions{FieldManager: FieldManager})
+}
+
+// finalizeFoo deletes or releases the children of a Foo that is being
+// deleted, depending on its deletion policy, and then removes FooFinalizer so
+// the deletion can complete.
+func (c *Controller) finalizeFoo(ctx context.Context, foo *samplev1alpha1.Foo) error {
+	if !hasFinalizer(foo) {
+		return nil
+	}
+	deployments, err := c.ownedDeployments(foo)
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		if err := c.deployments().finalize(ctx, foo, deployment); err != nil {
+			return err
+		}
+	}
+	service, err := c.services().owned(foo, foo.Name)
+	if err != nil {
+		return err
+	}
+	if service != nil {
+		if err := c.services().finalize(ctx, foo, service); err != nil {
+			return err
+		}
+	}
+	pdb, err := c.disruptionBudgets().owned(foo, foo.Name)
+	if err != nil {
+		return err
+	}
+	if pdb != nil {
+		if err := c.disruptionBudgets().finalize(ctx, foo, pdb); err != nil {
+			return err
+		}
+	}
+	hpa, err := c.autoscalers().owned(foo, foo.Name)
+	if err != nil {
+		return err
+	}
+	if hpa != nil {
+		if err := c.autoscalers().finalize(ctx, foo, hpa); err != nil {
+			return err
+		}
+	}
+
+	fooCopy := foo.DeepCopy()
+	fooCopy.Finalizers = slices.DeleteFunc(fooCopy.Finalizers, func(f string) bool { return f == FooFinalizer })
+	_, err = c.sampleclientset.SamplecontrollerV1alpha1().Foos(foo.Namespace).Update(ctx, fooCopy, metav1.UpdateOptions{FieldManager: FieldManager})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// controllerUIDIndex is the name of the Deployment index keyed by the UID of
+// the object controlling each Deployment.
+const controllerUIDIndex = "controllerUID"
+
+// controllerUIDIndexFunc indexes objects by the UID of their controller.
+func controllerUIDIndexFunc(obj interface{}) ([]string, error) {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if ref := metav1.GetControllerOf(object); ref != nil {
+		return []string{string(ref.UID)}, nil
+	}
+	return nil, nil
+}
+
+// deployments returns the kind of the Deployments controlled by Foos.
+func (c *Controller) deployments() childKind[appsv1.Deployment, *appsv1.Deployment, *appsv1ac.DeploymentApplyConfiguration] {
+	return childKind[appsv1.Deployment, *appsv1.Deployment, *appsv1ac.DeploymentApplyConfiguration]{
+		c:    c,
+		kind: "Deployment",
+		lister: func(namespace string) childLister[*appsv1.Deployment] {
+			return c.deploymentsLister.Deployments(namespace)
+		},
+		client: func(namespace string) childClient[*appsv1.Deployment, *appsv1ac.DeploymentApplyConfiguration] {
+			return c.kubeclientset.AppsV1().Deployments(namespace)
+		},
+	}
+}
+
+// ownedDeployments returns the Deployments controlled by the Foo, found by
+// the Foo's UID rather than by the Deployment name in its spec.
+func (c *Controller) ownedDeployments(foo *samplev1alpha1.Foo) ([]*appsv1.Deployment, error) {
+	objs, err := c.deploymentsIndexer.ByIndex(controllerUIDIndex, string(foo.UID))
+	if err != nil {
+		return nil, err
+	}
+	var owned []*appsv1.Deployment
+	for _, obj := range objs {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if ok && metav1.IsControlledBy(deployment, foo) {
+			owned = append(owned, deployment)
+		}
+	}
+	return owned, nil
+}
+
+// deleteStaleDeployments deletes the Deployments controlled by foo other than
+// active and its canary, which were left behind when spec.deploymentName
+// changed. They are kept serving until active has rolled out.
+func (c *Controller) deleteStaleDeployments(ctx context.Context, foo *samplev1alpha1.Foo, active *appsv1.Deployment) error {
+	deployments, err := c.ownedDeployments(foo)
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		if deployment.Name == active.Name || deployment.Name == canaryDeploymentName(foo) {
+			continue
+		}
+		if !deploymentRolledOut(active) {
+			klog.FromContext(ctx).V(4).Info("Waiting for deployment before deleting stale one", "deployment", klog.KObj(active), "stale", klog.KObj(deployment))
+			return nil
+		}
+		err := c.kubeclientset.AppsV1().Deployments(deployment.Namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(deployment.UID)),
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		c.recorder.Eventf(foo, deployment, corev1.EventTypeNormal, StaleDeploymentDeleted, ActionDelete, MessageStaleDeploymentDeleted, deployment.Name, active.Name)
+	}
+	return nil
+}
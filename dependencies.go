@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+const (
+	// ConfigHashAnnotation is set on the pod template of a Foo's Deployment
+	// and holds a hash of the ConfigMaps and Secrets the pods reference, so
+	// that changing them rolls the pods.
+	ConfigHashAnnotation = "samplecontroller.k8s.io/config-hash"
+
+	// configMapIndex and secretIndex are the names of the Foo indexes keyed
+	// by the namespace/name of the ConfigMaps and Secrets a Foo references.
+	configMapIndex = "configMap"
+	secretIndex    = "secret"
+)
+
+// podReferences lists the ConfigMaps and Secrets referenced by the pod
+// template of a Foo, by name. References that may be missing are marked
+// optional.
+type podReferences struct {
+	configMaps map[string]bool
+	secrets    map[string]bool
+}
+
+// addReference records a reference to name in refs. A name referenced both optionally
+// and as required is required.
+func addReference(refs map[string]bool, name string, optional *bool) {
+	if name == "" {
+		return
+	}
+	isOptional := ptr.Deref(optional, false)
+	if previous, ok := refs[name]; ok {
+		isOptional = isOptional && previous
+	}
+	refs[name] = isOptional
+}
+
+// fooReferences returns the ConfigMaps and Secrets used by the environment
+// and volumes of the pods of foo.
+func fooReferences(foo *samplev1alpha1.Foo) podReferences {
+	refs := podReferences{configMaps: map[string]bool{}, secrets: map[string]bool{}}
+	spec := &foo.Spec.Template.Spec
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				addReference(refs.configMaps, ref.Name, ref.Optional)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				addReference(refs.secrets, ref.Name, ref.Optional)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if ref := envFrom.ConfigMapRef; ref != nil {
+				addReference(refs.configMaps, ref.Name, ref.Optional)
+			}
+			if ref := envFrom.SecretRef; ref != nil {
+				addReference(refs.secrets, ref.Name, ref.Optional)
+			}
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if source := volume.ConfigMap; source != nil {
+			addReference(refs.configMaps, source.Name, source.Optional)
+		}
+		if source := volume.Secret; source != nil {
+			addReference(refs.secrets, source.SecretName, source.Optional)
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap != nil {
+				addReference(refs.configMaps, source.ConfigMap.Name, source.ConfigMap.Optional)
+			}
+			if source.Secret != nil {
+				addReference(refs.secrets, source.Secret.Name, source.Secret.Optional)
+			}
+		}
+	}
+	return refs
+}
+
+// referenceIndexFunc returns an index function keying Foos by the
+// namespace/name of the objects selected from their references by names.
+func referenceIndexFunc(names func(podReferences) map[string]bool) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		foo, ok := obj.(*samplev1alpha1.Foo)
+		if !ok {
+			return nil, nil
+		}
+		var keys []string
+		for name := range names(fooReferences(foo)) {
+			keys = append(keys, foo.Namespace+"/"+name)
+		}
+		return keys, nil
+	}
+}
+
+// handleDependency enqueues the Foos referencing the ConfigMap or Secret
+// obj, as found through index.
+func (c *Controller) handleDependency(index string, obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleErrorWithContext(context.Background(), err, "Error getting key of dependency")
+		return
+	}
+	foos, err := c.foosIndexer.ByIndex(index, key)
+	if err != nil {
+		utilruntime.HandleErrorWithContext(context.Background(), err, "Error looking up Foos referencing dependency", "index", index, "key", key)
+		return
+	}
+	for _, foo := range foos {
+		klog.FromContext(context.Background()).V(4).Info("Dependency changed", "index", index, "key", key, "foo", klog.KObj(foo.(*samplev1alpha1.Foo)))
+		c.enqueueFoo(foo)
+	}
+}
+
+// dependencyHash returns a hash of the content of the ConfigMaps and Secrets
+// the pods of foo reference, or an empty string if they reference none. The
+// required references that do not exist are returned as missing, described
+// as kind/name.
+func (c *Controller) dependencyHash(foo *samplev1alpha1.Foo) (string, []string, error) {
+	refs := fooReferences(foo)
+	if len(refs.configMaps) == 0 && len(refs.secrets) == 0 {
+		return "", nil, nil
+	}
+
+	var missing []string
+	configMaps := map[string]interface{}{}
+	for _, name := range sortedNames(refs.configMaps) {
+		configMap, err := c.configMapsLister.ConfigMaps(foo.Namespace).Get(name)
+		if errors.IsNotFound(err) {
+			if !refs.configMaps[name] {
+				missing = append(missing, fmt.Sprintf("ConfigMap/%s", name))
+			}
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		configMaps[name] = []interface{}{configMap.Data, configMap.BinaryData}
+	}
+	secrets := map[string]interface{}{}
+	for _, name := range sortedNames(refs.secrets) {
+		secret, err := c.secretsLister.Secrets(foo.Namespace).Get(name)
+		if errors.IsNotFound(err) {
+			if !refs.secrets[name] {
+				missing = append(missing, fmt.Sprintf("Secret/%s", name))
+			}
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		secrets[name] = secret.Data
+	}
+	if len(missing) > 0 {
+		return "", missing, nil
+	}
+	return computeHash([]interface{}{configMaps, secrets}), nil, nil
+}
+
+func sortedNames(refs map[string]bool) []string {
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
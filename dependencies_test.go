@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// newDependentFoo returns a Foo whose pods take their environment from the
+// ConfigMap "config" and mount the Secret "credentials".
+func newDependentFoo() *samplecontroller.Foo {
+	foo := newFoo("test", int32Ptr(1))
+	foo.Spec.Template.Spec = corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:    "app",
+			Image:   "app",
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}}}},
+		}},
+		Volumes: []corev1.Volume{{
+			Name:         "credentials",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "credentials"}},
+		}},
+	}
+	return foo
+}
+
+func newConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		Data:       data,
+	}
+}
+
+func newSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		Data:       data,
+	}
+}
+
+func TestFooReferences(t *testing.T) {
+	foo := newFoo("test", int32Ptr(1))
+	foo.Spec.Template.Spec = corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Name: "init",
+			Env: []corev1.EnvVar{{
+				Name:      "TOKEN",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token"}},
+			}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "app",
+			Env: []corev1.EnvVar{
+				{Name: "PLAIN", Value: "value"},
+				{
+					Name:      "LEVEL",
+					ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}, Key: "level", Optional: ptr.To(true)}},
+				},
+			},
+			EnvFrom: []corev1.EnvFromSource{
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "env"}}},
+				{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "env-secret"}, Optional: ptr.To(true)}},
+			},
+		}},
+		Volumes: []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}}},
+			{Name: "cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "cert"}}},
+			{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "bundle"}, Optional: ptr.To(true)}},
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}}},
+			}}}},
+			{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		},
+	}
+
+	refs := fooReferences(foo)
+	// "settings" is required by the volume even though the variable is
+	// optional.
+	expectedConfigMaps := map[string]bool{"settings": false, "env": false, "bundle": true}
+	expectedSecrets := map[string]bool{"token": false, "env-secret": true, "cert": false}
+	if !reflect.DeepEqual(refs.configMaps, expectedConfigMaps) {
+		t.Errorf("expected ConfigMaps %v, got %v", expectedConfigMaps, refs.configMaps)
+	}
+	if !reflect.DeepEqual(refs.secrets, expectedSecrets) {
+		t.Errorf("expected Secrets %v, got %v", expectedSecrets, refs.secrets)
+	}
+}
+
+func TestRolloutCarriesConfigHash(t *testing.T) {
+	f := newFixture(t)
+	foo := newDependentFoo()
+	_, ctx := ktesting.NewTestContext(t)
+
+	config := newConfigMap("config", map[string]string{"LEVEL": "debug"})
+	credentials := newSecret("credentials", map[string][]byte{"password": []byte("secret")})
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.configMapLister = append(f.configMapLister, config)
+	f.secretLister = append(f.secretLister, credentials)
+
+	c, _, k8sI := f.newController(ctx)
+	hash, missing, err := c.dependencyHash(foo)
+	if err != nil || len(missing) > 0 || hash == "" {
+		t.Fatalf("unexpected dependency hash %q, missing %v, error %v", hash, missing, err)
+	}
+
+	// Changing the content of a dependency changes the hash, and so the pod
+	// template of the Deployment.
+	changed := config.DeepCopy()
+	changed.Data["LEVEL"] = "info"
+	if err := k8sI.Core().V1().ConfigMaps().Informer().GetIndexer().Update(changed); err != nil {
+		t.Fatal(err)
+	}
+	changedHash, _, err := c.dependencyHash(foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changedHash == hash {
+		t.Errorf("expected the hash to change with the ConfigMap, still %q", hash)
+	}
+	if err := k8sI.Core().V1().ConfigMaps().Informer().GetIndexer().Update(config); err != nil {
+		t.Fatal(err)
+	}
+
+	expDeployment := newDeployment(foo, hash)
+	if expDeployment.Spec.Template.Annotations[ConfigHashAnnotation] != hash {
+		t.Fatalf("expected the pod template to carry the config hash, got %v", expDeployment.Spec.Template.Annotations)
+	}
+	f.expectApplyDeploymentAction(expDeployment)
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestMissingDependency(t *testing.T) {
+	f := newFixture(t)
+	foo := newDependentFoo()
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.secretLister = append(f.secretLister, newSecret("credentials", nil))
+
+	// The Deployment is not rolled out until the ConfigMap exists.
+	msg := fmt.Sprintf(MessageMissingDependency, "ConfigMap/config")
+	f.expectApplyFooStatusAction(withStatus(foo, samplecontroller.FooStatus{
+		LastSyncTime: &syncTime,
+		Conditions: []metav1.Condition{
+			{Type: samplecontroller.FooMissingDependency, Status: metav1.ConditionTrue, Reason: ErrMissingDependency, Message: msg, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooReady, Status: metav1.ConditionFalse, Reason: ErrMissingDependency, Message: msg, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooSuspended, Status: metav1.ConditionFalse, Reason: ReasonAsExpected, LastTransitionTime: syncTime},
+		},
+	}))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestOptionalDependencyMayBeMissing(t *testing.T) {
+	f := newFixture(t)
+	foo := newDependentFoo()
+	foo.Spec.Template.Spec.Containers[0].EnvFrom[0].ConfigMapRef.Optional = ptr.To(true)
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.secretLister = append(f.secretLister, newSecret("credentials", nil))
+
+	c, _, _ := f.newController(ctx)
+	hash, missing, err := c.dependencyHash(foo)
+	if err != nil || len(missing) > 0 {
+		t.Fatalf("unexpected missing dependencies %v, error %v", missing, err)
+	}
+
+	f.expectApplyDeploymentAction(newDeployment(foo, hash))
+	f.expectApplyFooStatusAction(withStatus(foo, rollingOutStatus(foo)))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestDependencyChangeEnqueuesFoo(t *testing.T) {
+	f := newFixture(t)
+	foo := newDependentFoo()
+	other := newFoo("other", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo, other)
+	f.objects = append(f.objects, foo, other)
+
+	c, _, _ := f.newController(ctx)
+	c.handleDependency(configMapIndex, newConfigMap("unrelated", nil))
+	if c.workqueue.Len() != 0 {
+		t.Fatalf("expected no Foo to be enqueued for an unreferenced ConfigMap, got %d", c.workqueue.Len())
+	}
+	c.handleDependency(secretIndex, cache.DeletedFinalStateUnknown{Key: "default/credentials", Obj: newSecret("credentials", nil)})
+	if c.workqueue.Len() != 1 {
+		t.Fatalf("expected the referencing Foo to be enqueued, got %d items", c.workqueue.Len())
+	}
+	item, _ := c.workqueue.Get()
+	if item != getRef(foo, t) {
+		t.Errorf("expected %v to be enqueued, got %v", getRef(foo, t), item)
+	}
+}
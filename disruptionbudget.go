@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	policyv1ac "k8s.io/client-go/applyconfigurations/policy/v1"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// disruptionBudgets returns the kind of the PodDisruptionBudgets controlled
+// by Foos.
+func (c *Controller) disruptionBudgets() childKind[policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudget, *policyv1ac.PodDisruptionBudgetApplyConfiguration] {
+	return childKind[policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudget, *policyv1ac.PodDisruptionBudgetApplyConfiguration]{
+		c:    c,
+		kind: "PodDisruptionBudget",
+		lister: func(namespace string) childLister[*policyv1.PodDisruptionBudget] {
+			return c.pdbsLister.PodDisruptionBudgets(namespace)
+		},
+		client: func(namespace string) childClient[*policyv1.PodDisruptionBudget, *policyv1ac.PodDisruptionBudgetApplyConfiguration] {
+			return c.kubeclientset.PolicyV1().PodDisruptionBudgets(namespace)
+		},
+	}
+}
+
+// syncDisruptionBudget makes the PodDisruptionBudget of foo match
+// spec.disruptionBudget and returns it, or deletes it and returns nil when
+// the Foo asks for none. Like the Service, a PodDisruptionBudget of the same
+// name that the Foo does not control is reported as a conflict.
+func (c *Controller) syncDisruptionBudget(ctx context.Context, foo *samplev1alpha1.Foo) (*policyv1.PodDisruptionBudget, error) {
+	if foo.Spec.DisruptionBudget == nil {
+		return nil, c.disruptionBudgets().delete(ctx, foo, foo.Name)
+	}
+	return c.disruptionBudgets().sync(ctx, foo, foo.Name, newDisruptionBudgetApplyConfiguration(foo))
+}
+
+// newDisruptionBudgetApplyConfiguration returns the PodDisruptionBudget
+// requested by the spec.disruptionBudget of foo. It is named after the Foo and
+// selects the pods of its Deployment.
+func newDisruptionBudgetApplyConfiguration(foo *samplev1alpha1.Foo) *policyv1ac.PodDisruptionBudgetApplyConfiguration {
+	spec := policyv1ac.PodDisruptionBudgetSpec().
+		WithSelector(metav1ac.LabelSelector().WithMatchLabels(selectorLabels(foo)))
+	if budget := foo.Spec.DisruptionBudget; budget.MinAvailable != nil {
+		spec.WithMinAvailable(*budget.MinAvailable)
+	} else if budget.MaxUnavailable != nil {
+		spec.WithMaxUnavailable(*budget.MaxUnavailable)
+	}
+
+	return policyv1ac.PodDisruptionBudget(foo.Name, foo.Namespace).
+		WithAnnotations(map[string]string{
+			DesiredStateHashAnnotation: computeHash(foo.Spec.DisruptionBudget),
+		}).
+		WithOwnerReferences(fooOwnerReference(foo)).
+		WithSpec(spec)
+}
+
+// disruptionBudgetStatus reports pdb in the status of a Foo.
+func disruptionBudgetStatus(pdb *policyv1.PodDisruptionBudget) *samplev1alpha1.FooDisruptionBudgetStatus {
+	if pdb == nil {
+		return nil
+	}
+	return &samplev1alpha1.FooDisruptionBudgetStatus{
+		Name:               pdb.Name,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/convert"
+)
+
+// newDisruptionBudget returns the PodDisruptionBudget of foo as the API
+// server stores it, with the disruption controller's view of its pods.
+func newDisruptionBudget(t *testing.T, foo *samplecontroller.Foo) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{}
+	if err := convert.ViaJSON(newDisruptionBudgetApplyConfiguration(foo), pdb); err != nil {
+		t.Fatal(err)
+	}
+	pdb.Status = policyv1.PodDisruptionBudgetStatus{
+		DisruptionsAllowed: 1,
+		CurrentHealthy:     3,
+		DesiredHealthy:     2,
+		ExpectedPods:       3,
+	}
+	return pdb
+}
+
+func TestDisruptionBudgetSwitchesBudget(t *testing.T) {
+	minAvailable := &samplecontroller.FooDisruptionBudget{MinAvailable: ptr.To(intstr.FromString("50%"))}
+	maxUnavailable := &samplecontroller.FooDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+	tests := []struct {
+		name     string
+		from, to *samplecontroller.FooDisruptionBudget
+	}{
+		{name: "MaxUnavailableToMinAvailable", from: maxUnavailable, to: minAvailable},
+		{name: "MinAvailableToMaxUnavailable", from: minAvailable, to: maxUnavailable},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+			foo := newFoo("test", int32Ptr(3))
+			foo.Spec.DisruptionBudget = test.from
+			_, ctx := ktesting.NewTestContext(t)
+
+			d := newDeployment(foo, "")
+			pdb := newDisruptionBudget(t, foo)
+			foo.Spec.DisruptionBudget = test.to
+
+			// Only the field the Foo asks for is applied, so the API server
+			// removes the other one the controller set before.
+			spec := newDisruptionBudgetApplyConfiguration(foo).Spec
+			if !reflect.DeepEqual(spec.MinAvailable, test.to.MinAvailable) || !reflect.DeepEqual(spec.MaxUnavailable, test.to.MaxUnavailable) {
+				t.Fatalf("expected only %+v to be applied, got minAvailable %v and maxUnavailable %v", test.to, spec.MinAvailable, spec.MaxUnavailable)
+			}
+
+			f.fooLister = append(f.fooLister, foo)
+			f.objects = append(f.objects, foo)
+			f.deploymentLister = append(f.deploymentLister, d)
+			f.pdbLister = append(f.pdbLister, pdb)
+			f.kubeobjects = append(f.kubeobjects, d, pdb)
+
+			f.expectApplyChildAction(disruptionBudgetChild, foo)
+			f.expectApplyFooStatusAction(withStatus(foo, disruptionBudgetChild.withStatus(rollingOutStatus(foo), pdb)))
+			f.run(ctx, getRef(foo, t))
+		})
+	}
+}
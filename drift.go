@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"k8s.io/sample-controller/pkg/convert"
+)
+
+const (
+	// DriftCorrected is used as part of the Event 'reason' when the controller
+	// reverts changes made to a Deployment behind the Foo's back
+	DriftCorrected = "DriftCorrected"
+	// MessageDriftCorrected is the message used for Events when drifted
+	// fields of a Deployment are reverted
+	MessageDriftCorrected = "Reverted drifted fields of Deployment %q: %s"
+)
+
+// deploymentDrift compares the fields the controller sets on desired with
+// their values in live and returns the paths of those that differ. Fields the
+// controller does not set, like server defaults, list items added by others
+// or extra labels and annotations, are not considered drift.
+func deploymentDrift(desired, live *appsv1.Deployment) ([]string, error) {
+	applyConfig, err := newDeploymentApplyConfiguration(desired)
+	if err != nil {
+		return nil, err
+	}
+	return applyConfigDrift(applyConfig, live)
+}
+
+// applyConfigDrift returns the paths of the metadata and spec fields set in
+// applyConfig whose value differs in the live object.
+func applyConfigDrift(applyConfig, live interface{}) ([]string, error) {
+	var want, got map[string]interface{}
+	if err := convert.ViaJSON(applyConfig, &want); err != nil {
+		return nil, err
+	}
+	if err := convert.ViaJSON(live, &got); err != nil {
+		return nil, err
+	}
+	return append(fieldDrift("metadata", want["metadata"], got["metadata"]),
+		fieldDrift("spec", want["spec"], got["spec"])...), nil
+}
+
+// fieldDrift returns the paths below path where got does not hold the value
+// set in want. Maps are compared key by key, lists whose items all carry a
+// name are matched by name and any other list must match item for item.
+func fieldDrift(path string, want, got interface{}) []string {
+	var drifted []string
+	switch want := want.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		gotMap, _ := got.(map[string]interface{})
+		keys := make([]string, 0, len(want))
+		for k := range want {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			drifted = append(drifted, fieldDrift(path+"."+k, want[k], gotMap[k])...)
+		}
+	case []interface{}:
+		gotList, _ := got.([]interface{})
+		wantByName, wantNamed := itemsByName(want)
+		gotByName, gotNamed := itemsByName(gotList)
+		if wantNamed && gotNamed {
+			for _, item := range want {
+				name := item.(map[string]interface{})["name"].(string)
+				drifted = append(drifted, fieldDrift(fmt.Sprintf("%s[name=%s]", path, name), wantByName[name], gotByName[name])...)
+			}
+			break
+		}
+		if len(want) != len(gotList) {
+			return []string{path}
+		}
+		for i := range want {
+			drifted = append(drifted, fieldDrift(fmt.Sprintf("%s[%d]", path, i), want[i], gotList[i])...)
+		}
+	default:
+		if !reflect.DeepEqual(want, got) {
+			return []string{path}
+		}
+	}
+	// A missing parent is reported once rather than by each of its fields.
+	if got == nil && len(drifted) > 0 {
+		return []string{path}
+	}
+	return drifted
+}
+
+// itemsByName indexes the items of list by their name. It reports false if
+// any item is not an object with a name.
+func itemsByName(list []interface{}) (map[string]interface{}, bool) {
+	byName := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := obj["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		byName[name] = obj
+	}
+	return byName, true
+}
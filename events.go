@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/tools/reference"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/lru"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+)
+
+// newEventRecorder returns a recorder of events.k8s.io/v1 Events, reported
+// by the controller and filtered as configured by cfg. The Events are
+// recorded until ctx is cancelled.
+func newEventRecorder(ctx context.Context, kubeclientset kubernetes.Interface, cfg *config.EventsConfiguration) events.EventRecorder {
+	logger := klog.FromContext(ctx)
+	logger.V(4).Info("Creating event broadcaster")
+
+	eventBroadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: kubeclientset.EventsV1()})
+	if _, err := eventBroadcaster.StartLogging(logger.V(int(cfg.LogVerbosity))); err != nil {
+		logger.Error(err, "Failed to log events")
+	}
+	eventBroadcaster.StartRecordingToSink(ctx.Done())
+	return newEventFilter(eventBroadcaster.NewRecorder(scheme.Scheme, controllerAgentName), cfg, clock.RealClock{})
+}
+
+// transitionReasons are the reasons of the Events recorded when a Foo or its
+// Deployment changes from one state to another. They are only recorded as the
+// change happens, so a repetition is a change back and forth, like a Foo
+// suspended again after being resumed, which deduplication would hide.
+var transitionReasons = sets.New(
+	ReconcileSuspended, ReconcileResumed,
+	DeploymentScaled, DeploymentUpdated, DriftCorrected,
+	ErrRolloutFailed, RolledBack,
+	CanaryProgressed, CanaryPaused, CanaryPromoted, CanaryAborted,
+)
+
+// eventFilter passes Events on to recorder, short of those that repeat an
+// Event recorded about the same object within the deduplication window, and
+// of those about an object that ran out of its burst of Events. Events with
+// one of the transitionReasons are only subject to the latter. The
+// broadcaster merges the remaining repetitions into an Event series.
+type eventFilter struct {
+	recorder events.EventRecorder
+	clock    clock.PassiveClock
+	window   time.Duration
+	qps      float32
+	burst    int
+
+	lock sync.Mutex
+	// objects holds the eventHistory of the objects Events were recorded
+	// about, forgetting the least recently used ones.
+	objects *lru.Cache
+}
+
+// eventHistory is what eventFilter remembers of the Events about an object.
+type eventHistory struct {
+	limiter flowcontrol.PassiveRateLimiter
+	// recorded holds when each Event was last recorded.
+	recorded map[eventKey]time.Time
+}
+
+// eventKey identifies the repetitions of an Event about an object.
+type eventKey struct {
+	eventtype, reason, action, note string
+	related                         corev1.ObjectReference
+}
+
+func newEventFilter(recorder events.EventRecorder, cfg *config.EventsConfiguration, clock clock.PassiveClock) *eventFilter {
+	return &eventFilter{
+		recorder: recorder,
+		clock:    clock,
+		window:   cfg.DeduplicationWindow.Duration,
+		qps:      cfg.QPS,
+		burst:    int(cfg.Burst),
+		objects:  lru.New(int(cfg.CacheSize)),
+	}
+}
+
+var _ events.EventRecorder = &eventFilter{}
+
+// Eventf records an Event about regarding, unless it is filtered out.
+func (f *eventFilter) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	note = fmt.Sprintf(note, args...)
+	key := eventKey{eventtype: eventtype, reason: reason, action: action, note: note}
+	if f.record(regarding, related, key, !transitionReasons.Has(reason)) {
+		f.recorder.Eventf(regarding, related, eventtype, reason, action, "%s", note)
+	}
+}
+
+// record reports whether the Event identified by key should be recorded, and
+// remembers it if so. Repetitions of it are only dropped if dedup is set.
+// Events about objects that have no reference are always recorded.
+func (f *eventFilter) record(regarding, related runtime.Object, key eventKey, dedup bool) bool {
+	ref, err := objectReference(regarding)
+	if err != nil {
+		return true
+	}
+	if related != nil {
+		if relatedRef, err := objectReference(related); err == nil {
+			key.related = relatedRef
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	now := f.clock.Now()
+	var history *eventHistory
+	if value, ok := f.objects.Get(ref); ok {
+		history = value.(*eventHistory)
+	} else {
+		history = &eventHistory{
+			limiter:  flowcontrol.NewTokenBucketPassiveRateLimiterWithClock(f.qps, f.burst, f.clock),
+			recorded: map[eventKey]time.Time{},
+		}
+		f.objects.Add(ref, history)
+	}
+	for k, recorded := range history.recorded {
+		if now.Sub(recorded) >= f.window {
+			delete(history.recorded, k)
+		}
+	}
+	if _, ok := history.recorded[key]; ok && dedup {
+		return false
+	}
+	if !history.limiter.TryAccept() {
+		return false
+	}
+	if dedup {
+		history.recorded[key] = now
+	}
+	return true
+}
+
+// objectReference returns the reference to obj, which stays the same as obj
+// is updated.
+func objectReference(obj runtime.Object) (corev1.ObjectReference, error) {
+	ref, err := reference.GetReference(scheme.Scheme, obj)
+	if err != nil {
+		return corev1.ObjectReference{}, err
+	}
+	ref.ResourceVersion = ""
+	return *ref, nil
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2/ktesting"
+	testingclock "k8s.io/utils/clock/testing"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+func newTestEventFilter(cfg config.EventsConfiguration) (*eventFilter, *events.FakeRecorder, *testingclock.FakeClock) {
+	recorder := events.NewFakeRecorder(10)
+	clock := testingclock.NewFakeClock(syncTime.Time)
+	return newEventFilter(recorder, &cfg, clock), recorder, clock
+}
+
+func newPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault, UID: types.UID("uid-" + name)}}
+}
+
+// drainEvents returns the Events recorded so far.
+func drainEvents(recorder *events.FakeRecorder) []string {
+	var recorded []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
+func TestEventFilterDeduplicates(t *testing.T) {
+	filter, recorder, clock := newTestEventFilter(config.EventsConfiguration{
+		QPS:                 1,
+		Burst:               10,
+		DeduplicationWindow: metav1.Duration{Duration: time.Minute},
+		CacheSize:           10,
+	})
+	pod := newPod("a")
+	deployment := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: metav1.NamespaceDefault}}
+
+	filter.Eventf(pod, nil, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", 2)
+	// An update of the object does not make its Events new.
+	pod.ResourceVersion = "2"
+	filter.Eventf(pod, nil, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", 2)
+	if recorded := drainEvents(recorder); len(recorded) != 1 {
+		t.Errorf("expected the repeated Event to be dropped, got %v", recorded)
+	}
+
+	filter.Eventf(pod, nil, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", 3)
+	filter.Eventf(pod, deployment, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", 2)
+	if recorded := drainEvents(recorder); len(recorded) != 2 {
+		t.Errorf("expected Events with another note or related object to be recorded, got %v", recorded)
+	}
+
+	clock.Step(time.Minute)
+	filter.Eventf(pod, nil, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", 2)
+	if recorded := drainEvents(recorder); len(recorded) != 1 {
+		t.Errorf("expected the Event to be recorded again after the window, got %v", recorded)
+	}
+}
+
+func TestEventFilterRecordsFlipFlops(t *testing.T) {
+	filter, recorder, _ := newTestEventFilter(config.EventsConfiguration{
+		QPS:                 1,
+		Burst:               10,
+		DeduplicationWindow: metav1.Duration{Duration: time.Minute},
+		CacheSize:           10,
+	})
+	foo := newFoo("test", int32Ptr(3))
+	deployment := &apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: foo.Spec.DeploymentName, Namespace: metav1.NamespaceDefault}}
+
+	// Suspended, resumed and suspended again.
+	filter.Eventf(foo, nil, corev1.EventTypeNormal, ReconcileSuspended, ActionSync, MessageSuspendedBySpec)
+	filter.Eventf(foo, nil, corev1.EventTypeNormal, ReconcileResumed, ActionSync, MessageResumed)
+	filter.Eventf(foo, nil, corev1.EventTypeNormal, ReconcileSuspended, ActionSync, MessageSuspendedBySpec)
+	// Scaled from 3 to 5, back to 3 and to 5 again.
+	for _, replicas := range [][2]int{{3, 5}, {5, 3}, {3, 5}} {
+		filter.Eventf(foo, deployment, corev1.EventTypeNormal, DeploymentScaled, ActionScale, MessageDeploymentScaled, deployment.Name, replicas[0], replicas[1])
+	}
+	if recorded := drainEvents(recorder); len(recorded) != 6 {
+		t.Errorf("expected every transition to be recorded, got %v", recorded)
+	}
+}
+
+func TestEventFilterLimitsRate(t *testing.T) {
+	filter, recorder, clock := newTestEventFilter(config.EventsConfiguration{
+		QPS:                 1. / 60.,
+		Burst:               2,
+		DeduplicationWindow: metav1.Duration{Duration: time.Minute},
+		CacheSize:           10,
+	})
+
+	for i := range 3 {
+		filter.Eventf(newPod("a"), nil, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", i)
+	}
+	filter.Eventf(newPod("b"), nil, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", 0)
+	if recorded := drainEvents(recorder); len(recorded) != 3 {
+		t.Errorf("expected the burst of each object to be recorded, got %v", recorded)
+	}
+
+	clock.Step(time.Minute)
+	filter.Eventf(newPod("a"), nil, corev1.EventTypeNormal, "Scaled", ActionScale, "Scaled to %d", 3)
+	if recorded := drainEvents(recorder); len(recorded) != 1 {
+		t.Errorf("expected an Event once the rate allows it, got %v", recorded)
+	}
+}
+
+// syncRecordingEvents syncs foo once and returns the Events recorded.
+func (f *fixture) syncRecordingEvents(ctx context.Context, foo *samplecontroller.Foo) ([]string, error) {
+	c, i, k8sI := f.newController(ctx)
+	recorder := events.NewFakeRecorder(10)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	err := c.syncHandler(ctx, getRef(foo, f.t))
+	return drainEvents(recorder), err
+}
+
+func TestRecordsDeploymentCreated(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	recorded, err := f.syncRecordingEvents(ctx, foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := fmt.Sprintf("%s %s "+MessageDeploymentCreated, corev1.EventTypeNormal, DeploymentCreated, foo.Spec.DeploymentName, 1)
+	if len(recorded) != 1 || recorded[0] != expected {
+		t.Errorf("expected %q, got %v", expected, recorded)
+	}
+}
+
+func TestRecordsDeploymentScaled(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	foo.Spec.Replicas = int32Ptr(2)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	recorded, err := f.syncRecordingEvents(ctx, foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := fmt.Sprintf("%s %s "+MessageDeploymentScaled, corev1.EventTypeNormal, DeploymentScaled, d.Name, 1, 2)
+	if len(recorded) != 1 || recorded[0] != expected {
+		t.Errorf("expected %q, got %v", expected, recorded)
+	}
+}
+
+func TestRecordsNoEventsInSync(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.Status = availableStatus(1)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	recorded, err := f.syncRecordingEvents(ctx, foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != 0 {
+		t.Errorf("expected no Events for a Foo in sync, got %v", recorded)
+	}
+}
+
+func TestRecordsConflictOnce(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.OwnerReferences = nil
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	recorded, err := f.syncRecordingEvents(ctx, foo)
+	if err == nil {
+		t.Fatal("expected the conflict to fail the sync")
+	}
+	if len(recorded) != 1 || !strings.Contains(recorded[0], ErrResourceExists) {
+		t.Errorf("expected a %s Event, got %v", ErrResourceExists, recorded)
+	}
+
+	// The retry finds the conflict reported already.
+	f = newFixture(t)
+	foo.Status.Conditions = []metav1.Condition{{
+		Type:    samplecontroller.FooResourceConflict,
+		Status:  metav1.ConditionTrue,
+		Reason:  ErrResourceExists,
+		Message: fmt.Sprintf(MessageResourceExists, d.Name),
+	}}
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	recorded, err = f.syncRecordingEvents(ctx, foo)
+	if err == nil {
+		t.Fatal("expected the conflict to fail the sync")
+	}
+	if len(recorded) != 0 {
+		t.Errorf("expected no Event for a conflict reported already, got %v", recorded)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/sample-controller/pkg/healthz"
+)
+
+// workerStuckThreshold is how long a single sync may run before the workers
+// are considered wedged.
+const workerStuckThreshold = 5 * time.Minute
+
+// HealthzChecks returns the liveness checks of the controller.
+func (c *Controller) HealthzChecks() []healthz.Checker {
+	return []healthz.Checker{
+		healthz.NamedCheck("workers", c.checkWorkers),
+	}
+}
+
+// ReadyzChecks returns the readiness checks of the controller.
+func (c *Controller) ReadyzChecks() []healthz.Checker {
+	return []healthz.Checker{
+		healthz.NamedCheck("informer-sync", c.checkCachesSynced),
+	}
+}
+
+// checkCachesSynced fails until Run has synced the informer caches.
+func (c *Controller) checkCachesSynced(*http.Request) error {
+	if !c.cachesSynced.Load() {
+		return fmt.Errorf("informer caches not synced")
+	}
+	return nil
+}
+
+// checkWorkers fails if any sync has been running for longer than
+// workerStuckThreshold.
+func (c *Controller) checkWorkers(*http.Request) error {
+	c.syncsLock.Lock()
+	defer c.syncsLock.Unlock()
+	now := c.clock.Now()
+	for objRef, start := range c.syncStarts {
+		if running := now.Sub(start); running > workerStuckThreshold {
+			return fmt.Errorf("sync of %s running for %s", objRef, running.Round(time.Second))
+		}
+	}
+	return nil
+}
+
+// startSync records that a worker started syncing objRef. The returned
+// function records that it finished.
+func (c *Controller) startSync(objRef cache.ObjectName) func() {
+	c.syncsLock.Lock()
+	defer c.syncsLock.Unlock()
+	c.syncStarts[objRef] = c.clock.Now()
+	return func() {
+		c.syncsLock.Lock()
+		defer c.syncsLock.Unlock()
+		delete(c.syncStarts, objRef)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2/ktesting"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestCheckWorkers(t *testing.T) {
+	f := newFixture(t)
+	_, ctx := ktesting.NewTestContext(t)
+	c, _, _ := f.newController(ctx)
+	clock := testingclock.NewFakePassiveClock(syncTime.Time)
+	c.clock = clock
+
+	if err := c.checkWorkers(nil); err != nil {
+		t.Errorf("expected idle workers to be healthy, got %v", err)
+	}
+
+	done := c.startSync(cache.ObjectName{Namespace: "default", Name: "test"})
+	clock.SetTime(syncTime.Add(time.Minute))
+	if err := c.checkWorkers(nil); err != nil {
+		t.Errorf("expected a running sync to be healthy, got %v", err)
+	}
+	clock.SetTime(syncTime.Add(workerStuckThreshold + time.Second))
+	if err := c.checkWorkers(nil); err == nil {
+		t.Error("expected a wedged sync to fail the check")
+	}
+	done()
+	if err := c.checkWorkers(nil); err != nil {
+		t.Errorf("expected finished syncs to be healthy, got %v", err)
+	}
+}
+
+func TestCheckCachesSynced(t *testing.T) {
+	f := newFixture(t)
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, i, k8sI := f.newController(ctx)
+
+	if err := c.checkCachesSynced(nil); err == nil {
+		t.Error("expected the check to fail before the caches are synced")
+	}
+
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+	errCh := make(chan error)
+	go func() { errCh <- c.Run(ctx) }()
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return c.checkCachesSynced(nil) == nil, nil
+	}); err != nil {
+		t.Errorf("expected the check to pass once Run synced the caches: %v", err)
+	}
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Errorf("error running controller: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+)
+
+// leaderElectionConfig holds the settings of the Lease used to elect the
+// replica running the controller.
+type leaderElectionConfig struct {
+	// LeaseName and LeaseNamespace identify the Lease object.
+	LeaseName      string
+	LeaseNamespace string
+	// LeaseDuration is how long standbys wait before taking over a Lease
+	// that was not renewed.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long the leader keeps retrying to renew the Lease
+	// before giving up leadership.
+	RenewDeadline time.Duration
+	// RetryPeriod is how long candidates wait between attempts to acquire or
+	// renew the Lease.
+	RetryPeriod time.Duration
+}
+
+// defaultLeaseNamespace returns the namespace of the controller's Pod, from
+// the POD_NAMESPACE environment variable set through the downward API, so the
+// Lease lives where the controller is allowed to write it. Outside of a Pod it
+// falls back to kube-system.
+func defaultLeaseNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	return metav1.NamespaceSystem
+}
+
+// runWithLeaderElection blocks until ctx is cancelled, calling run once this
+// process holds the Lease. When ctx is cancelled, the Lease is released as
+// soon as run has returned, so a standby can take over without waiting for it
+// to expire. Losing the Lease while ctx is still live ends the process, as
+// another replica may already be reconciling the same Foos.
+//
+// watchDog, if not nil, is wired to the elector so that it fails once this
+// process holds the Lease but has not renewed it in time.
+func runWithLeaderElection(ctx context.Context, kubeClient kubernetes.Interface, config leaderElectionConfig, watchDog *leaderelection.HealthzAdaptor, run func(ctx context.Context)) {
+	logger := klog.FromContext(ctx)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Error(err, "Error getting hostname")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+	// Add a unique suffix so that two processes on the same host never
+	// accidentally both become active.
+	identity := hostname + "_" + string(uuid.NewUUID())
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      config.LeaseName,
+			Namespace: config.LeaseNamespace,
+		},
+		Client: kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	// The election outlives ctx until run has stopped, since releasing the
+	// Lease while workers are still writing would let a standby race them.
+	electionCtx, cancelElection := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelElection()
+	var leading atomic.Bool
+	stop := context.AfterFunc(ctx, func() {
+		if !leading.Load() {
+			cancelElection()
+		}
+	})
+	defer stop()
+
+	leaderelection.RunOrDie(electionCtx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   config.LeaseDuration,
+		RenewDeadline:   config.RenewDeadline,
+		RetryPeriod:     config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            config.LeaseName,
+		WatchDog:        watchDog,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				leading.Store(true)
+				defer cancelElection()
+				runCtx, cancelRun := context.WithCancel(leaderCtx)
+				defer cancelRun()
+				stop := context.AfterFunc(ctx, cancelRun)
+				defer stop()
+				run(runCtx)
+			},
+			OnStoppedLeading: func() {
+				if ctx.Err() != nil {
+					logger.Info("Released leader lease", "identity", identity)
+					return
+				}
+				logger.Error(nil, "Lost leader lease", "identity", identity)
+				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+			},
+			OnNewLeader: func(current string) {
+				if current == identity {
+					return
+				}
+				logger.Info("New leader elected", "identity", current)
+			},
+		},
+	})
+}
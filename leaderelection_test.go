@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog/v2/ktesting"
+)
+
+func TestLeaderElectionReleasesLease(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	kubeClient := k8sfake.NewClientset()
+	config := leaderElectionConfig{
+		LeaseName:      "sample-controller",
+		LeaseNamespace: metav1.NamespaceSystem,
+		LeaseDuration:  15 * time.Second,
+		RenewDeadline:  10 * time.Second,
+		RetryPeriod:    100 * time.Millisecond,
+	}
+
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runWithLeaderElection(ctx, kubeClient, config, nil, func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			close(stopped)
+		})
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting to become leader")
+	}
+	lease, err := kubeClient.CoordinationV1().Leases(config.LeaseNamespace).Get(ctx, config.LeaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		t.Fatalf("expected the lease to be held, got %+v", lease.Spec)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for leader election to stop")
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Error("expected run to return before the lease was released")
+	}
+
+	lease, err = kubeClient.CoordinationV1().Leases(config.LeaseNamespace).Get(context.Background(), config.LeaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" {
+		t.Errorf("expected the lease to be released, still held by %q", *lease.Spec.HolderIdentity)
+	}
+}
+
+func TestDefaultLeaseNamespace(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "")
+	if got := defaultLeaseNamespace(); got != metav1.NamespaceSystem {
+		t.Errorf("expected %q without POD_NAMESPACE, got %q", metav1.NamespaceSystem, got)
+	}
+	t.Setenv("POD_NAMESPACE", "team-a")
+	if got := defaultLeaseNamespace(); got != "team-a" {
+		t.Errorf("expected the namespace of the Pod, got %q", got)
+	}
+}
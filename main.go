@@ -17,20 +17,32 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
 
-	kubeinformers "k8s.io/client-go/informers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/klog/v2"
+	"k8s.io/sample-controller/pkg/healthz"
+	"k8s.io/sample-controller/pkg/metrics"
 	"k8s.io/sample-controller/pkg/signals"
+	"k8s.io/sample-controller/pkg/tracing"
+	"k8s.io/sample-controller/pkg/webhook"
 
 	// Uncomment the following line to load the gcp plugin (only required to authenticate against GKE clusters).
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/install"
 	clientset "k8s.io/sample-controller/pkg/generated/clientset/versioned"
-	informers "k8s.io/sample-controller/pkg/generated/informers/externalversions"
 )
 
 // masterURL 和 kubeconfig 是支持命令行传参的变量。
@@ -39,6 +51,22 @@ import (
 var (
 	masterURL  string
 	kubeconfig string
+
+	webhookBindAddress string
+	tlsCertFile        string
+	tlsPrivateKeyFile  string
+
+	leaderElect    bool
+	leaderElection leaderElectionConfig
+
+	metricsBindAddress string
+
+	tracingEndpoint      string
+	tracingSamplingRatio float64
+
+	healthProbeBindAddress string
+
+	configFile string
 )
 
 func main() {
@@ -48,6 +76,30 @@ func main() {
 	// set up signals so we handle the shutdown signal gracefully
 	ctx := signals.SetupSignalHandler()
 	logger := klog.FromContext(ctx)
+	// 尽早订阅 SIGHUP, 以免启动期间收到的信号终止进程.
+	reloads := signals.NotifyReload(ctx)
+
+	// 指标需要在创建客户端和工作队列之前注册, 否则它们不会上报数据.
+	if metricsBindAddress != "" {
+		metrics.Register()
+		go func() {
+			if err := metrics.Serve(ctx, metricsBindAddress); err != nil {
+				logger.Error(err, "Error running metrics server")
+				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+			}
+		}()
+	}
+
+	// 读取配置文件, 命令行上显式给出的参数覆盖文件中的值.
+	controllerConfig, err := loadConfiguration(configFile, flag.CommandLine)
+	if err != nil {
+		logger.Error(err, "Error loading configuration")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+	if err := setLogVerbosity(controllerConfig.LogVerbosity); err != nil {
+		logger.Error(err, "Error setting log verbosity")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
 
 	// 根据 masterURL 和 kubeconfig 生成 Kubernetes 访问配置。
 	// 如果 masterURL 和 kubeconfig 都是空字符串，BuildConfigFromFlags 里面会进一步调用：rest.InClusterConfig()（即使用 Pod 内置的环境变量、ServiceAccount Token 来连接 Kubernetes API）
@@ -58,6 +110,24 @@ func main() {
 		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 	}
 
+	// 开启链路追踪后, 每次调谐的排队等待, 缓存查询及 API 请求都记录为 span, 通过 OTLP 导出.
+	// 客户端的 transport 被包装, 以便 API 请求携带调谐的 trace context.
+	if tracingEndpoint != "" {
+		shutdown, err := tracing.Setup(ctx, tracingEndpoint, tracingSamplingRatio)
+		if err != nil {
+			logger.Error(err, "Error setting up tracing")
+			klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				logger.Error(err, "Error flushing traces")
+			}
+		}()
+		cfg.Wrap(tracing.WrapTransport)
+	}
+
 	// kubeClient是client-go下的客户端库, exampleClient是代码生成器创建的客户端。
 	// 这两个客户端都是用来和 Kubernetes API Server 进行 HTTP 通信的，区别在于它们操作的资源不同：
 	// 1. kubeClient 操作的是 Kubernetes 原生资源，比如 Deployment、Service、Pod 等。除了自定义资源组别不能操作, 其它都能操作。
@@ -85,30 +155,111 @@ func main() {
 	// Informer 是核心组件, 作用是 watch 和 list k8s 资源, 然后将资源本地化, 减低对 apiServer 的压力。
 
 	// SharedInformerFactory 负责管理和复用各种资源的 informer，监听资源变化（比如 Deployment 变化）并缓存到本地。
-	// 这里的 kubeInformerFactory 是 Kubernetes 原生资源的 InformerFactory，用于创建和管理 Kubernetes 资源的 Informer。
-	// 这里的 exampleInformerFactory 是自定义资源的 InformerFactory，用于创建和管理自定义资源的 Informer。
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, time.Second*30)
-	exampleInformerFactory := informers.NewSharedInformerFactory(exampleClient, time.Second*30)
+	// informerScope 为每个监听的命名空间分别创建 Kubernetes 原生资源和自定义资源的 InformerFactory,
+	// 不限定命名空间时只有一组监听整个集群的 InformerFactory。
+	resync := controllerConfig.ResyncPeriod.Duration
+	scope := newInformerScope(kubeClient, exampleClient, resync)
 
 	// 创建一个 Controller 实例，传入要监听的资源。
 	// 这里控制器监听了 Deployment 和 Foo 两种资源的变化。
-	controller := NewController(ctx, kubeClient, exampleClient,
-		kubeInformerFactory.Apps().V1().Deployments(),
-		exampleInformerFactory.Samplecontroller().V1alpha1().Foos())
+	controller := NewController(ctx, kubeClient, exampleClient, scope, controllerConfig)
+
+	// 收到 SIGHUP 时重新读取配置文件, 可以在运行时修改的设置 (日志级别, worker 数量,
+	// 限速器, 暂停的命名空间) 立即生效, 其余设置的修改会被拒绝. 结果记录为控制器
+	// 所在 Pod 的事件, 需要通过 downward API 设置 POD_NAME 和 POD_NAMESPACE.
+	if name, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE"); name != "" && namespace != "" {
+		controller.reloadTarget = &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: name, Namespace: namespace}
+	}
+	go controller.reloadOnSignal(ctx, reloads, configFile, flag.CommandLine)
 
 	// 启动全部已注册的 informers 及运行 controller.
 	// 启动 InformerFactory，它们内部会建立 Watch，实时监听资源变化。
 	// notice that there is no need to run Start methods in a separate goroutine. (i.e. go kubeInformerFactory.Start(ctx.done())
 	// Start method is non-blocking and runs all registered informers in a dedicated goroutine.
-	kubeInformerFactory.Start(ctx.Done())
-	exampleInformerFactory.Start(ctx.Done())
+	scope.start(ctx)
+	switch {
+	case controllerConfig.NamespaceSelector != "":
+		// 命名空间通过标签自行加入, 标签被移除后停止监听该命名空间.
+		if err := watchNamespaces(ctx, kubeClient, resync, controllerConfig.NamespaceSelector, scope); err != nil {
+			logger.Error(err, "Error watching namespaces")
+			klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+		}
+	case len(controllerConfig.Namespaces) > 0:
+		for _, namespace := range controllerConfig.Namespaces {
+			scope.addNamespace(namespace)
+		}
+	default:
+		scope.addNamespace(metav1.NamespaceAll)
+	}
+
+	// 启动 webhook 服务，apiserver 通过它在 Foo 的各个 API 版本之间转换对象，
+	// 并在 Foo 创建和更新时为它设置默认值并校验它。
+	if webhookBindAddress != "" {
+		webhookScheme := runtime.NewScheme()
+		install.Install(webhookScheme)
+
+		webhookServer := webhook.NewServer(webhookBindAddress, tlsCertFile, tlsPrivateKeyFile)
+		webhookServer.Handle("/convert", webhook.NewConversionHandler(webhookScheme))
+		webhookServer.Handle("/default-foo", webhook.NewFooDefaultingHandler(webhookScheme))
+		webhookServer.Handle("/validate-foo", webhook.NewFooValidationHandler(controller.deploymentsLister))
+		go func() {
+			if err := webhookServer.Run(ctx); err != nil {
+				logger.Error(err, "Error running webhook server")
+				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+			}
+		}()
+	}
 
 	// 启动控制器，开始处理资源变化。
-	// 这里会开启 2 个 worker 线程并发来处理资源变化。
-	if err = controller.Run(ctx, 2); err != nil {
-		logger.Error(err, "Error running controller")
-		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	// worker 线程的数量由配置中的 workers 决定, 默认为 2 个。
+	var leading atomic.Bool
+	run := func(ctx context.Context) {
+		leading.Store(true)
+		if err := controller.Run(ctx); err != nil {
+			logger.Error(err, "Error running controller")
+			klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+		}
+	}
+
+	// 健康检查: /healthz 用于存活探针, /readyz 用于就绪探针, 各个子系统可以注册自己的检查项.
+	var watchDog *leaderelection.HealthzAdaptor
+	if healthProbeBindAddress != "" {
+		probes := healthz.NewServer(healthProbeBindAddress)
+		probes.AddHealthzChecks(healthz.PingCheck, healthz.NamedCheck("shutdown", func(*http.Request) error {
+			if ctx.Err() != nil {
+				return fmt.Errorf("shutting down")
+			}
+			return nil
+		}))
+		probes.AddHealthzChecks(controller.HealthzChecks()...)
+		probes.AddReadyzChecks(healthz.PingCheck)
+		probes.AddReadyzChecks(controller.ReadyzChecks()...)
+		if leaderElect {
+			// Give the leader some slack over the renew deadline before it
+			// is restarted for not renewing its Lease.
+			watchDog = leaderelection.NewLeaderHealthzAdaptor(20 * time.Second)
+			probes.AddHealthzChecks(watchDog)
+			probes.AddReadyzChecks(healthz.NamedCheck("leader-election", func(*http.Request) error {
+				if !leading.Load() {
+					return fmt.Errorf("waiting to acquire the leader lease")
+				}
+				return nil
+			}))
+		}
+		go func() {
+			if err := probes.Run(ctx); err != nil {
+				logger.Error(err, "Error running health probe server")
+				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+			}
+		}()
+	}
+
+	// 开启选主后, 只有持有 Lease 的副本运行控制器, 其余副本的 informer 保持同步以便随时接管.
+	if !leaderElect {
+		run(ctx)
+		return
 	}
+	runWithLeaderElection(ctx, kubeClient, leaderElection, watchDog, run)
 }
 
 // 初始化命令行参数，在程序启动时注册命令行参数，设置 --kubeconfig 和 --master。
@@ -116,4 +267,19 @@ func main() {
 func init() {
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&webhookBindAddress, "webhook-bind-address", "", "The address the webhook server binds to, e.g. :9443. The webhook server is disabled if empty.")
+	flag.StringVar(&tlsCertFile, "tls-cert-file", "", "File containing the x509 certificate served by the webhook server.")
+	flag.StringVar(&tlsPrivateKeyFile, "tls-private-key-file", "", "File containing the x509 private key matching --tls-cert-file.")
+	flag.StringVar(&metricsBindAddress, "metrics-bind-address", "", "The address the Prometheus metrics endpoint binds to, e.g. :8080. Metrics are not served if empty.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "", "The URL of the OTLP/HTTP collector traces are exported to, e.g. http://localhost:4318. Tracing is disabled if empty.")
+	flag.Float64Var(&tracingSamplingRatio, "tracing-sampling-ratio", 1, "The share of reconciliations traced, between 0 and 1.")
+	flag.StringVar(&healthProbeBindAddress, "health-probe-bind-address", "", "The address the /healthz and /readyz probe endpoints bind to, e.g. :8081. Probes are not served if empty.")
+	flag.StringVar(&configFile, "config", "", "Path to a SampleControllerConfiguration file. Flags given on the command line override its settings.")
+	addConfigFlags(flag.CommandLine)
+	flag.BoolVar(&leaderElect, "leader-elect", false, "Start a leader election client and gain leadership before running the controller, so that several replicas can run with only one of them active.")
+	flag.StringVar(&leaderElection.LeaseName, "leader-elect-resource-name", "sample-controller", "The name of the Lease object used for leader election.")
+	flag.StringVar(&leaderElection.LeaseNamespace, "leader-elect-namespace", defaultLeaseNamespace(), "The namespace of the Lease object used for leader election. Defaults to the namespace of the controller's Pod, from the POD_NAMESPACE environment variable, or kube-system.")
+	flag.DurationVar(&leaderElection.LeaseDuration, "leader-elect-lease-duration", 15*time.Second, "The duration that non-leader candidates wait after observing a leadership renewal before attempting to acquire leadership.")
+	flag.DurationVar(&leaderElection.RenewDeadline, "leader-elect-renew-deadline", 10*time.Second, "The interval between attempts by the leader to renew its leadership before it stops leading. Must be less than the lease duration.")
+	flag.DurationVar(&leaderElection.RetryPeriod, "leader-elect-retry-period", 2*time.Second, "The duration candidates wait between attempts to acquire or renew leadership.")
 }
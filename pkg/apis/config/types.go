@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SampleControllerConfiguration is the internal configuration of the sample
+// controller
+type SampleControllerConfiguration struct {
+	metav1.TypeMeta
+
+	// Workers is the number of Foos synced concurrently.
+	Workers int32
+	// ResyncPeriod is how often the informers replay their caches.
+	ResyncPeriod metav1.Duration
+	// Namespaces are the namespaces watched, all of them if empty.
+	Namespaces []string
+	// NamespaceSelector selects the namespaces watched by their labels.
+	NamespaceSelector string
+	// FooSelector selects the Foos reconciled by their labels.
+	FooSelector string
+	// RateLimiter configures the retries of failed syncs.
+	RateLimiter RateLimiterConfiguration
+	// Events configures the Events emitted by the controller.
+	Events EventsConfiguration
+	// LogVerbosity is the klog verbosity of the controller.
+	LogVerbosity int32
+	// SuspendedNamespaces are the namespaces whose Foos are suspended.
+	SuspendedNamespaces []string
+}
+
+// RateLimiterConfiguration is the internal configuration of the rate limiter
+// of the workqueue
+type RateLimiterConfiguration struct {
+	BaseDelay metav1.Duration
+	MaxDelay  metav1.Duration
+	QPS       float32
+	Burst     int32
+}
+
+// EventsConfiguration is the internal configuration of the Events emitted by
+// the controller
+type EventsConfiguration struct {
+	QPS                 float32
+	Burst               int32
+	DeduplicationWindow metav1.Duration
+	CacheSize           int32
+	LogVerbosity        int32
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_SampleControllerConfiguration fills in the settings left unset
+// with the values the controller used before it could be configured.
+func SetDefaults_SampleControllerConfiguration(obj *SampleControllerConfiguration) {
+	if obj.Workers == 0 {
+		obj.Workers = 2
+	}
+	if obj.ResyncPeriod.Duration == 0 {
+		obj.ResyncPeriod.Duration = 30 * time.Second
+	}
+	if obj.RateLimiter.BaseDelay.Duration == 0 {
+		obj.RateLimiter.BaseDelay.Duration = 5 * time.Millisecond
+	}
+	if obj.RateLimiter.MaxDelay.Duration == 0 {
+		obj.RateLimiter.MaxDelay.Duration = 1000 * time.Second
+	}
+	if obj.RateLimiter.QPS == 0 {
+		obj.RateLimiter.QPS = 50
+	}
+	if obj.RateLimiter.Burst == 0 {
+		obj.RateLimiter.Burst = 300
+	}
+	// The defaults of the Event recorder's spam filter.
+	if obj.Events.QPS == 0 {
+		obj.Events.QPS = 1. / 300.
+	}
+	if obj.Events.Burst == 0 {
+		obj.Events.Burst = 25
+	}
+	// And of its correlator.
+	if obj.Events.DeduplicationWindow.Duration == 0 {
+		obj.Events.DeduplicationWindow.Duration = 10 * time.Minute
+	}
+	if obj.Events.CacheSize == 0 {
+		obj.Events.CacheSize = 4096
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SampleControllerConfiguration configures the sample controller. It is read
+// from the file given to --config; flags given on the command line override
+// the values in the file.
+type SampleControllerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Workers is the number of Foos synced concurrently. Defaults to 2.
+	// +optional
+	Workers int32 `json:"workers,omitempty"`
+
+	// ResyncPeriod is how often the informers replay their caches, which
+	// syncs every Foo again. Defaults to 30s.
+	// +optional
+	ResyncPeriod metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// Namespaces are the namespaces whose Foos are reconciled. All
+	// namespaces are watched if empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector is a label selector; the Foos of the namespaces
+	// matching it are reconciled. Namespaces opt in and out by being
+	// labelled. Mutually exclusive with Namespaces.
+	// +optional
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+
+	// FooSelector is a label selector restricting the Foos reconciled. All
+	// Foos are reconciled if empty. All Foos are still cached, so that those
+	// whose labels stop matching can be finalized.
+	// +optional
+	FooSelector string `json:"fooSelector,omitempty"`
+
+	// RateLimiter configures how the syncs of Foos are retried.
+	// +optional
+	RateLimiter RateLimiterConfiguration `json:"rateLimiter"`
+
+	// Events configures the Events emitted by the controller.
+	// +optional
+	Events EventsConfiguration `json:"events"`
+
+	// LogVerbosity is the klog verbosity of the controller, as set by -v.
+	// Defaults to 0.
+	// +optional
+	LogVerbosity int32 `json:"logVerbosity,omitempty"`
+
+	// SuspendedNamespaces are namespaces whose Foos are suspended as if
+	// they set spec.suspend, e.g. during maintenance of a team's workloads.
+	// +optional
+	SuspendedNamespaces []string `json:"suspendedNamespaces,omitempty"`
+}
+
+// RateLimiterConfiguration configures the rate limiter of the workqueue. A
+// Foo is retried after the larger of its own backoff and the delay imposed by
+// the overall rate limit.
+type RateLimiterConfiguration struct {
+	// BaseDelay is the delay before the first retry of a failed sync. It
+	// doubles with every further failure. Defaults to 5ms.
+	// +optional
+	BaseDelay metav1.Duration `json:"baseDelay,omitempty"`
+
+	// MaxDelay caps the delay between the retries of a Foo. Defaults to
+	// 1000s.
+	// +optional
+	MaxDelay metav1.Duration `json:"maxDelay,omitempty"`
+
+	// QPS is the rate at which Foos are requeued overall. Defaults to 50.
+	// +optional
+	QPS float32 `json:"qps,omitempty"`
+
+	// Burst is the number of Foos that may be requeued at once above QPS.
+	// Defaults to 300.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// EventsConfiguration configures how the controller records Events.
+type EventsConfiguration struct {
+	// QPS is the rate at which Events about a single object are recorded
+	// once Burst is exhausted; further Events are dropped. Defaults to one
+	// every five minutes.
+	// +optional
+	QPS float32 `json:"qps,omitempty"`
+
+	// Burst is the number of Events about a single object recorded before
+	// QPS applies. Defaults to 25.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+
+	// DeduplicationWindow is how long an Event is not recorded again about
+	// the same object with the same type, reason, action, related object and
+	// note. The Events of state changes, like scaling the Deployment or
+	// suspending the Foo, are not deduplicated. Defaults to 10m.
+	// +optional
+	DeduplicationWindow metav1.Duration `json:"deduplicationWindow,omitempty"`
+
+	// CacheSize is the number of objects whose recent Events are
+	// remembered, for deduplication and for QPS and Burst. Defaults to 4096.
+	// +optional
+	CacheSize int32 `json:"cacheSize,omitempty"`
+
+	// LogVerbosity is the klog verbosity at which Events are also logged.
+	// Defaults to 0, logging every Event.
+	// +optional
+	LogVerbosity int32 `json:"logVerbosity,omitempty"`
+}
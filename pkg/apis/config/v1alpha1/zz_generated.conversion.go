@@ -0,0 +1,168 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	unsafe "unsafe"
+
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	config "k8s.io/sample-controller/pkg/apis/config"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*EventsConfiguration)(nil), (*config.EventsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EventsConfiguration_To_config_EventsConfiguration(a.(*EventsConfiguration), b.(*config.EventsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.EventsConfiguration)(nil), (*EventsConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_EventsConfiguration_To_v1alpha1_EventsConfiguration(a.(*config.EventsConfiguration), b.(*EventsConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RateLimiterConfiguration)(nil), (*config.RateLimiterConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RateLimiterConfiguration_To_config_RateLimiterConfiguration(a.(*RateLimiterConfiguration), b.(*config.RateLimiterConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RateLimiterConfiguration)(nil), (*RateLimiterConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RateLimiterConfiguration_To_v1alpha1_RateLimiterConfiguration(a.(*config.RateLimiterConfiguration), b.(*RateLimiterConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SampleControllerConfiguration)(nil), (*config.SampleControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SampleControllerConfiguration_To_config_SampleControllerConfiguration(a.(*SampleControllerConfiguration), b.(*config.SampleControllerConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.SampleControllerConfiguration)(nil), (*SampleControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_SampleControllerConfiguration_To_v1alpha1_SampleControllerConfiguration(a.(*config.SampleControllerConfiguration), b.(*SampleControllerConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_EventsConfiguration_To_config_EventsConfiguration(in *EventsConfiguration, out *config.EventsConfiguration, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.DeduplicationWindow = in.DeduplicationWindow
+	out.CacheSize = in.CacheSize
+	out.LogVerbosity = in.LogVerbosity
+	return nil
+}
+
+// Convert_v1alpha1_EventsConfiguration_To_config_EventsConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_EventsConfiguration_To_config_EventsConfiguration(in *EventsConfiguration, out *config.EventsConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EventsConfiguration_To_config_EventsConfiguration(in, out, s)
+}
+
+func autoConvert_config_EventsConfiguration_To_v1alpha1_EventsConfiguration(in *config.EventsConfiguration, out *EventsConfiguration, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.DeduplicationWindow = in.DeduplicationWindow
+	out.CacheSize = in.CacheSize
+	out.LogVerbosity = in.LogVerbosity
+	return nil
+}
+
+// Convert_config_EventsConfiguration_To_v1alpha1_EventsConfiguration is an autogenerated conversion function.
+func Convert_config_EventsConfiguration_To_v1alpha1_EventsConfiguration(in *config.EventsConfiguration, out *EventsConfiguration, s conversion.Scope) error {
+	return autoConvert_config_EventsConfiguration_To_v1alpha1_EventsConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_RateLimiterConfiguration_To_config_RateLimiterConfiguration(in *RateLimiterConfiguration, out *config.RateLimiterConfiguration, s conversion.Scope) error {
+	out.BaseDelay = in.BaseDelay
+	out.MaxDelay = in.MaxDelay
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	return nil
+}
+
+// Convert_v1alpha1_RateLimiterConfiguration_To_config_RateLimiterConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_RateLimiterConfiguration_To_config_RateLimiterConfiguration(in *RateLimiterConfiguration, out *config.RateLimiterConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RateLimiterConfiguration_To_config_RateLimiterConfiguration(in, out, s)
+}
+
+func autoConvert_config_RateLimiterConfiguration_To_v1alpha1_RateLimiterConfiguration(in *config.RateLimiterConfiguration, out *RateLimiterConfiguration, s conversion.Scope) error {
+	out.BaseDelay = in.BaseDelay
+	out.MaxDelay = in.MaxDelay
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	return nil
+}
+
+// Convert_config_RateLimiterConfiguration_To_v1alpha1_RateLimiterConfiguration is an autogenerated conversion function.
+func Convert_config_RateLimiterConfiguration_To_v1alpha1_RateLimiterConfiguration(in *config.RateLimiterConfiguration, out *RateLimiterConfiguration, s conversion.Scope) error {
+	return autoConvert_config_RateLimiterConfiguration_To_v1alpha1_RateLimiterConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_SampleControllerConfiguration_To_config_SampleControllerConfiguration(in *SampleControllerConfiguration, out *config.SampleControllerConfiguration, s conversion.Scope) error {
+	out.Workers = in.Workers
+	out.ResyncPeriod = in.ResyncPeriod
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = in.NamespaceSelector
+	out.FooSelector = in.FooSelector
+	if err := Convert_v1alpha1_RateLimiterConfiguration_To_config_RateLimiterConfiguration(&in.RateLimiter, &out.RateLimiter, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_EventsConfiguration_To_config_EventsConfiguration(&in.Events, &out.Events, s); err != nil {
+		return err
+	}
+	out.LogVerbosity = in.LogVerbosity
+	out.SuspendedNamespaces = *(*[]string)(unsafe.Pointer(&in.SuspendedNamespaces))
+	return nil
+}
+
+// Convert_v1alpha1_SampleControllerConfiguration_To_config_SampleControllerConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_SampleControllerConfiguration_To_config_SampleControllerConfiguration(in *SampleControllerConfiguration, out *config.SampleControllerConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SampleControllerConfiguration_To_config_SampleControllerConfiguration(in, out, s)
+}
+
+func autoConvert_config_SampleControllerConfiguration_To_v1alpha1_SampleControllerConfiguration(in *config.SampleControllerConfiguration, out *SampleControllerConfiguration, s conversion.Scope) error {
+	out.Workers = in.Workers
+	out.ResyncPeriod = in.ResyncPeriod
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = in.NamespaceSelector
+	out.FooSelector = in.FooSelector
+	if err := Convert_config_RateLimiterConfiguration_To_v1alpha1_RateLimiterConfiguration(&in.RateLimiter, &out.RateLimiter, s); err != nil {
+		return err
+	}
+	if err := Convert_config_EventsConfiguration_To_v1alpha1_EventsConfiguration(&in.Events, &out.Events, s); err != nil {
+		return err
+	}
+	out.LogVerbosity = in.LogVerbosity
+	out.SuspendedNamespaces = *(*[]string)(unsafe.Pointer(&in.SuspendedNamespaces))
+	return nil
+}
+
+// Convert_config_SampleControllerConfiguration_To_v1alpha1_SampleControllerConfiguration is an autogenerated conversion function.
+func Convert_config_SampleControllerConfiguration_To_v1alpha1_SampleControllerConfiguration(in *config.SampleControllerConfiguration, out *SampleControllerConfiguration, s conversion.Scope) error {
+	return autoConvert_config_SampleControllerConfiguration_To_v1alpha1_SampleControllerConfiguration(in, out, s)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates the configuration of the sample controller.
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+)
+
+// ValidateSampleControllerConfiguration tests that a defaulted configuration
+// is usable.
+func ValidateSampleControllerConfiguration(cfg *config.SampleControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.Workers <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("workers"), cfg.Workers, "must be greater than 0"))
+	}
+	if cfg.ResyncPeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("resyncPeriod"), cfg.ResyncPeriod.Duration.String(), "must not be negative"))
+	}
+
+	allErrs = append(allErrs, validateNamespaces(cfg.Namespaces, field.NewPath("namespaces"))...)
+	if cfg.NamespaceSelector != "" {
+		if len(cfg.Namespaces) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("namespaceSelector"), "may not be set together with namespaces"))
+		}
+		allErrs = append(allErrs, validateSelector(cfg.NamespaceSelector, field.NewPath("namespaceSelector"))...)
+	}
+	allErrs = append(allErrs, validateSelector(cfg.FooSelector, field.NewPath("fooSelector"))...)
+
+	allErrs = append(allErrs, validateRateLimiter(&cfg.RateLimiter, field.NewPath("rateLimiter"))...)
+	allErrs = append(allErrs, validateEvents(&cfg.Events, field.NewPath("events"))...)
+	if cfg.LogVerbosity < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("logVerbosity"), cfg.LogVerbosity, "must not be negative"))
+	}
+	allErrs = append(allErrs, validateNamespaces(cfg.SuspendedNamespaces, field.NewPath("suspendedNamespaces"))...)
+	return allErrs
+}
+
+func validateNamespaces(namespaces []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.New[string]()
+	for i, namespace := range namespaces {
+		for _, msg := range validation.ValidateNamespaceName(namespace, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), namespace, msg))
+		}
+		if seen.Has(namespace) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), namespace))
+		}
+		seen.Insert(namespace)
+	}
+	return allErrs
+}
+
+func validateSelector(selector string, fldPath *field.Path) field.ErrorList {
+	_, err := labels.Parse(selector)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, selector, err.Error())}
+	}
+	return nil
+}
+
+func validateRateLimiter(rateLimiter *config.RateLimiterConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rateLimiter.BaseDelay.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("baseDelay"), rateLimiter.BaseDelay.Duration.String(), "must be greater than 0"))
+	}
+	if rateLimiter.MaxDelay.Duration < rateLimiter.BaseDelay.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxDelay"), rateLimiter.MaxDelay.Duration.String(), "must not be less than baseDelay"))
+	}
+	if rateLimiter.QPS <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), rateLimiter.QPS, "must be greater than 0"))
+	}
+	if rateLimiter.Burst <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), rateLimiter.Burst, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateEvents(events *config.EventsConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if events.QPS <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), events.QPS, "must be greater than 0"))
+	}
+	if events.Burst <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), events.Burst, "must be greater than 0"))
+	}
+	if events.DeduplicationWindow.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("deduplicationWindow"), events.DeduplicationWindow.Duration.String(), "must not be negative"))
+	}
+	if events.CacheSize <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cacheSize"), events.CacheSize, "must be greater than 0"))
+	}
+	if events.LogVerbosity < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("logVerbosity"), events.LogVerbosity, "must not be negative"))
+	}
+	return allErrs
+}
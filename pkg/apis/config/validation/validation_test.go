@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+)
+
+func newConfiguration() *config.SampleControllerConfiguration {
+	return &config.SampleControllerConfiguration{
+		Workers:      2,
+		ResyncPeriod: metav1.Duration{Duration: 30 * time.Second},
+		RateLimiter: config.RateLimiterConfiguration{
+			BaseDelay: metav1.Duration{Duration: 5 * time.Millisecond},
+			MaxDelay:  metav1.Duration{Duration: 1000 * time.Second},
+			QPS:       50,
+			Burst:     300,
+		},
+		Events: config.EventsConfiguration{
+			QPS:                 1. / 300.,
+			Burst:               25,
+			DeduplicationWindow: metav1.Duration{Duration: 10 * time.Minute},
+			CacheSize:           4096,
+		},
+	}
+}
+
+func TestValidateSampleControllerConfiguration(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mutate         func(cfg *config.SampleControllerConfiguration)
+		expectedFields []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(cfg *config.SampleControllerConfiguration) {},
+		},
+		{
+			name:           "no workers",
+			mutate:         func(cfg *config.SampleControllerConfiguration) { cfg.Workers = 0 },
+			expectedFields: []string{"workers"},
+		},
+		{
+			name:           "negative resync period",
+			mutate:         func(cfg *config.SampleControllerConfiguration) { cfg.ResyncPeriod.Duration = -time.Second },
+			expectedFields: []string{"resyncPeriod"},
+		},
+		{
+			name:   "namespaces",
+			mutate: func(cfg *config.SampleControllerConfiguration) { cfg.Namespaces = []string{"team-a", "team-b"} },
+		},
+		{
+			name: "invalid and duplicate namespaces",
+			mutate: func(cfg *config.SampleControllerConfiguration) {
+				cfg.Namespaces = []string{"Team_A", "team-b", "team-b"}
+			},
+			expectedFields: []string{"namespaces[0]", "namespaces[2]"},
+		},
+		{
+			name: "namespaces and namespace selector",
+			mutate: func(cfg *config.SampleControllerConfiguration) {
+				cfg.Namespaces = []string{"team-a"}
+				cfg.NamespaceSelector = "samplecontroller.k8s.io/enabled=true"
+			},
+			expectedFields: []string{"namespaceSelector"},
+		},
+		{
+			name: "invalid selectors",
+			mutate: func(cfg *config.SampleControllerConfiguration) {
+				cfg.NamespaceSelector = "-enabled=true"
+				cfg.FooSelector = "shard in (1"
+			},
+			expectedFields: []string{"namespaceSelector", "fooSelector"},
+		},
+		{
+			name: "invalid rate limiter",
+			mutate: func(cfg *config.SampleControllerConfiguration) {
+				cfg.RateLimiter = config.RateLimiterConfiguration{
+					BaseDelay: metav1.Duration{Duration: time.Second},
+					MaxDelay:  metav1.Duration{Duration: time.Millisecond},
+				}
+			},
+			expectedFields: []string{"rateLimiter.maxDelay", "rateLimiter.qps", "rateLimiter.burst"},
+		},
+		{
+			name: "invalid events",
+			mutate: func(cfg *config.SampleControllerConfiguration) {
+				cfg.Events = config.EventsConfiguration{
+					DeduplicationWindow: metav1.Duration{Duration: -time.Minute},
+					LogVerbosity:        -1,
+				}
+			},
+			expectedFields: []string{"events.qps", "events.burst", "events.deduplicationWindow", "events.cacheSize", "events.logVerbosity"},
+		},
+		{
+			name: "invalid log verbosity and suspended namespaces",
+			mutate: func(cfg *config.SampleControllerConfiguration) {
+				cfg.LogVerbosity = -1
+				cfg.SuspendedNamespaces = []string{"team-a", "team-a"}
+			},
+			expectedFields: []string{"logVerbosity", "suspendedNamespaces[1]"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newConfiguration()
+			tc.mutate(cfg)
+			checkErrorFields(t, ValidateSampleControllerConfiguration(cfg), tc.expectedFields)
+		})
+	}
+}
+
+func checkErrorFields(t *testing.T, errs field.ErrorList, expected []string) {
+	t.Helper()
+	if len(errs) != len(expected) {
+		t.Fatalf("expected errors for %v, got %v", expected, errs)
+	}
+	for i := range errs {
+		if errs[i].Field != expected[i] {
+			t.Errorf("expected error %d for %s, got %v", i, expected[i], errs[i])
+		}
+	}
+}
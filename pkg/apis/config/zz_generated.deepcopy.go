@@ -0,0 +1,99 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package config
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventsConfiguration) DeepCopyInto(out *EventsConfiguration) {
+	*out = *in
+	out.DeduplicationWindow = in.DeduplicationWindow
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventsConfiguration.
+func (in *EventsConfiguration) DeepCopy() *EventsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EventsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimiterConfiguration) DeepCopyInto(out *RateLimiterConfiguration) {
+	*out = *in
+	out.BaseDelay = in.BaseDelay
+	out.MaxDelay = in.MaxDelay
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimiterConfiguration.
+func (in *RateLimiterConfiguration) DeepCopy() *RateLimiterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimiterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleControllerConfiguration) DeepCopyInto(out *SampleControllerConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ResyncPeriod = in.ResyncPeriod
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.RateLimiter = in.RateLimiter
+	out.Events = in.Events
+	if in.SuspendedNamespaces != nil {
+		in, out := &in.SuspendedNamespaces, &out.SuspendedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleControllerConfiguration.
+func (in *SampleControllerConfiguration) DeepCopy() *SampleControllerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleControllerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SampleControllerConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
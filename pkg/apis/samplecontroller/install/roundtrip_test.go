@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/apitesting/roundtrip"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/randfill"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller"
+)
+
+// fooFuzzerFuncs keeps the pod templates simple; fuzzing every field of a
+// core/v1 PodSpec produces values (e.g. quantities) that only round-trip in
+// their canonical form. Fields defaulted when decoding v1alpha1 are always
+// set, as an empty value would not survive the round trip.
+func fooFuzzerFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		func(j *samplecontroller.FooSpec, c randfill.Continue) {
+			c.FillNoCustom(j)
+			j.Template = corev1.PodTemplateSpec{}
+			c.Fill(&j.Template.Labels)
+			c.Fill(&j.Template.Annotations)
+			j.Template.Spec.Containers = []corev1.Container{
+				{Name: c.String(0), Image: c.String(0)},
+			}
+			if j.Deployment.Name == "" {
+				j.Deployment.Name = "foo"
+			}
+			if j.Deployment.Replicas == nil {
+				j.Deployment.Replicas = new(int32)
+			}
+			if j.DeletionPolicy == "" {
+				j.DeletionPolicy = samplecontroller.DeletionPolicyDelete
+			}
+			if j.Service != nil {
+				defaultServicePorts(j.Service.Ports)
+				if len(j.Service.Annotations) == 0 {
+					j.Service.Annotations = nil
+				}
+			}
+		},
+		func(j *samplecontroller.FooStatus, c randfill.Continue) {
+			c.FillNoCustom(j)
+			if j.Service != nil {
+				defaultServicePorts(j.Service.Ports)
+			}
+			if j.Rollout != nil && j.Rollout.LastGoodTemplate != nil {
+				j.Rollout.LastGoodTemplate = &corev1.PodTemplateSpec{}
+				c.Fill(&j.Rollout.LastGoodTemplate.Labels)
+				j.Rollout.LastGoodTemplate.Spec.Containers = []corev1.Container{
+					{Name: c.String(0), Image: c.String(0)},
+				}
+			}
+		},
+	}
+}
+
+// defaultServicePorts sets the protocol that is defaulted on every port.
+func defaultServicePorts(ports []corev1.ServicePort) {
+	for i := range ports {
+		if ports[i].Protocol == "" {
+			ports[i].Protocol = corev1.ProtocolTCP
+		}
+	}
+}
+
+var fuzzSeed = flag.Int64("fuzz-seed", 0, "seed of the round-trip fuzzer, random when 0")
+
+func TestRoundTripTypes(t *testing.T) {
+	seed := *fuzzSeed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	t.Logf("Fuzzing with seed %d, pass -fuzz-seed=%d to reproduce", seed, seed)
+
+	scheme := runtime.NewScheme()
+	Install(scheme)
+	codecs := runtimeserializer.NewCodecFactory(scheme)
+	f := fuzzer.FuzzerFor(
+		fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, fooFuzzerFuncs),
+		rand.NewSource(seed),
+		codecs,
+	)
+
+	roundtrip.RoundTripTypesWithoutProtobuf(t, scheme, codecs, f, nil)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package samplecontroller
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Foo is the internal representation of a Foo resource
+type Foo struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   FooSpec
+	Status FooStatus
+}
+
+// FooSpec is the internal spec for a Foo resource
+type FooSpec struct {
+	// Deployment configures the Deployment managed for this Foo.
+	Deployment FooDeployment
+	// Template describes the pods that will be created for this Foo.
+	Template corev1.PodTemplateSpec
+	// DeletionPolicy decides what happens to the objects owned by this Foo
+	// when the Foo is deleted.
+	DeletionPolicy DeletionPolicy
+	// Service configures the Service managed for this Foo, if any.
+	Service *FooService
+	// Suspend stops the controller from changing the objects owned by this
+	// Foo while its status is still reported.
+	Suspend *bool
+	// DisruptionBudget configures the PodDisruptionBudget managed for this
+	// Foo, if any.
+	DisruptionBudget *FooDisruptionBudget
+	// Autoscaling configures the HorizontalPodAutoscaler managed for this
+	// Foo, if any.
+	Autoscaling *FooAutoscaling
+	// Rollback configures how failed rollouts of the Deployment are
+	// handled.
+	Rollback *FooRollback
+	// Strategy configures how changes of the template are rolled out.
+	Strategy *FooStrategy
+}
+
+// FooStrategy describes how changes of the template of a Foo are rolled out
+type FooStrategy struct {
+	Canary *FooCanaryStrategy
+}
+
+// FooCanaryStrategy describes the steps of a canary rollout
+type FooCanaryStrategy struct {
+	Steps []FooCanaryStep
+}
+
+// FooCanaryStep is a single step of a canary rollout
+type FooCanaryStep struct {
+	Replicas intstr.IntOrString
+	Pause    *FooCanaryPause
+}
+
+// FooCanaryPause holds a canary rollout at a step
+type FooCanaryPause struct {
+	Duration *metav1.Duration
+}
+
+// FooRollback describes how failed rollouts of a Foo are handled
+type FooRollback struct {
+	Automatic bool
+}
+
+// FooAutoscaling describes the HorizontalPodAutoscaler owned by a Foo
+type FooAutoscaling struct {
+	MinReplicas *int32
+	MaxReplicas int32
+	Metrics     []autoscalingv2.MetricSpec
+}
+
+// FooDisruptionBudget describes the PodDisruptionBudget owned by a Foo
+type FooDisruptionBudget struct {
+	MinAvailable   *intstr.IntOrString
+	MaxUnavailable *intstr.IntOrString
+}
+
+// FooService describes the Service owned by a Foo
+type FooService struct {
+	Type        corev1.ServiceType
+	Ports       []corev1.ServicePort
+	Annotations map[string]string
+}
+
+// FooDeployment describes the Deployment owned by a Foo
+type FooDeployment struct {
+	Name     string
+	Replicas *int32
+}
+
+// DeletionPolicy describes how the objects owned by a Foo are treated when
+// the Foo is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the owned objects together with the Foo.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan releases the owned objects by removing the Foo's
+	// owner reference, leaving them running on their own.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyRetain releases the owned objects like Orphan, but marks
+	// them so that a Foo re-created with the same name adopts them again.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// FooStatus is the internal status for a Foo resource
+type FooStatus struct {
+	DeploymentName     string
+	Replicas           int32
+	Selector           string
+	AvailableReplicas  int32
+	ReadyReplicas      int32
+	UpdatedReplicas    int32
+	ObservedGeneration int64
+	LastSyncTime       *metav1.Time
+	Conditions         []metav1.Condition
+	Service            *FooServiceStatus
+	DisruptionBudget   *FooDisruptionBudgetStatus
+	Autoscaling        *FooAutoscalingStatus
+	Rollout            *FooRolloutStatus
+	Canary             *FooCanaryStatus
+}
+
+// FooServiceStatus is the internal status of the Service owned by a Foo
+type FooServiceStatus struct {
+	Name      string
+	ClusterIP string
+	Ports     []corev1.ServicePort
+}
+
+// FooDisruptionBudgetStatus is the internal status of the
+// PodDisruptionBudget owned by a Foo
+type FooDisruptionBudgetStatus struct {
+	Name               string
+	DisruptionsAllowed int32
+	CurrentHealthy     int32
+	DesiredHealthy     int32
+}
+
+// FooAutoscalingStatus is the internal status of the HorizontalPodAutoscaler
+// owned by a Foo
+type FooAutoscalingStatus struct {
+	Name            string
+	CurrentReplicas int32
+	DesiredReplicas int32
+}
+
+// FooRolloutStatus is the internal status of the rollouts of a Foo
+type FooRolloutStatus struct {
+	LastGoodRevision     string
+	LastGoodTemplateHash string
+	LastGoodTemplate     *corev1.PodTemplateSpec
+	FailedRevision       string
+	FailedTemplateHash   string
+}
+
+// FooCanaryPhase is the phase of a canary rollout
+type FooCanaryPhase string
+
+// FooCanaryStatus is the internal status of the canary rollout of a Foo
+type FooCanaryStatus struct {
+	DeploymentName    string
+	TemplateHash      string
+	Phase             FooCanaryPhase
+	Step              int32
+	Replicas          int32
+	AvailableReplicas int32
+	PauseStartTime    *metav1.Time
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FooList is a list of Foo resources
+type FooList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []Foo
+}
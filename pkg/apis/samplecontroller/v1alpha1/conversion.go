@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller"
+)
+
+// Convert_v1alpha1_FooSpec_To_samplecontroller_FooSpec moves the flat
+// deploymentName and replicas fields of v1alpha1 into spec.deployment.
+func Convert_v1alpha1_FooSpec_To_samplecontroller_FooSpec(in *FooSpec, out *samplecontroller.FooSpec, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_FooSpec_To_samplecontroller_FooSpec(in, out, s); err != nil {
+		return err
+	}
+	out.Deployment.Name = in.DeploymentName
+	out.Deployment.Replicas = in.Replicas
+	return nil
+}
+
+// Convert_samplecontroller_FooSpec_To_v1alpha1_FooSpec flattens
+// spec.deployment back into the v1alpha1 deploymentName and replicas fields.
+func Convert_samplecontroller_FooSpec_To_v1alpha1_FooSpec(in *samplecontroller.FooSpec, out *FooSpec, s conversion.Scope) error {
+	if err := autoConvert_samplecontroller_FooSpec_To_v1alpha1_FooSpec(in, out, s); err != nil {
+		return err
+	}
+	out.DeploymentName = in.Deployment.Name
+	out.Replicas = in.Deployment.Replicas
+	return nil
+}
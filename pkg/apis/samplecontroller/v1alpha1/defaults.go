@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NameLabel is set by the defaulting webhook on the pod template of a new Foo
+// that does not label its pods itself. It holds the name of the Foo.
+const NameLabel = "app.kubernetes.io/name"
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_Foo fills in the fields of foo left unset by its author. The
+// Deployment is named after the Foo and runs a single replica. None of the
+// defaults changes the pod template, so applying them to a stored Foo does not
+// roll its pods.
+func SetDefaults_Foo(obj *Foo) {
+	if obj.Spec.DeploymentName == "" {
+		obj.Spec.DeploymentName = obj.Name
+	}
+	if obj.Spec.Replicas == nil {
+		replicas := int32(1)
+		obj.Spec.Replicas = &replicas
+	}
+	if obj.Spec.DeletionPolicy == "" {
+		obj.Spec.DeletionPolicy = DeletionPolicyDelete
+	}
+}
@@ -15,6 +15,8 @@ limitations under the License.
 */
 
 // +k8s:deepcopy-gen=package
+// +k8s:conversion-gen=k8s.io/sample-controller/pkg/apis/samplecontroller
+// +k8s:defaulter-gen=TypeMeta
 // +groupName=samplecontroller.k8s.io
 
 // Package v1alpha1 is the v1alpha1 version of the API.
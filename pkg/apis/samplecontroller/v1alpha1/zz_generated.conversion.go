@@ -0,0 +1,667 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	unsafe "unsafe"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*Foo)(nil), (*samplecontroller.Foo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Foo_To_samplecontroller_Foo(a.(*Foo), b.(*samplecontroller.Foo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.Foo)(nil), (*Foo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_Foo_To_v1alpha1_Foo(a.(*samplecontroller.Foo), b.(*Foo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooAutoscaling)(nil), (*samplecontroller.FooAutoscaling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooAutoscaling_To_samplecontroller_FooAutoscaling(a.(*FooAutoscaling), b.(*samplecontroller.FooAutoscaling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooAutoscaling)(nil), (*FooAutoscaling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooAutoscaling_To_v1alpha1_FooAutoscaling(a.(*samplecontroller.FooAutoscaling), b.(*FooAutoscaling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooAutoscalingStatus)(nil), (*samplecontroller.FooAutoscalingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooAutoscalingStatus_To_samplecontroller_FooAutoscalingStatus(a.(*FooAutoscalingStatus), b.(*samplecontroller.FooAutoscalingStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooAutoscalingStatus)(nil), (*FooAutoscalingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooAutoscalingStatus_To_v1alpha1_FooAutoscalingStatus(a.(*samplecontroller.FooAutoscalingStatus), b.(*FooAutoscalingStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooCanaryPause)(nil), (*samplecontroller.FooCanaryPause)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooCanaryPause_To_samplecontroller_FooCanaryPause(a.(*FooCanaryPause), b.(*samplecontroller.FooCanaryPause), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooCanaryPause)(nil), (*FooCanaryPause)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooCanaryPause_To_v1alpha1_FooCanaryPause(a.(*samplecontroller.FooCanaryPause), b.(*FooCanaryPause), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooCanaryStatus)(nil), (*samplecontroller.FooCanaryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooCanaryStatus_To_samplecontroller_FooCanaryStatus(a.(*FooCanaryStatus), b.(*samplecontroller.FooCanaryStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooCanaryStatus)(nil), (*FooCanaryStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooCanaryStatus_To_v1alpha1_FooCanaryStatus(a.(*samplecontroller.FooCanaryStatus), b.(*FooCanaryStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooCanaryStep)(nil), (*samplecontroller.FooCanaryStep)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooCanaryStep_To_samplecontroller_FooCanaryStep(a.(*FooCanaryStep), b.(*samplecontroller.FooCanaryStep), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooCanaryStep)(nil), (*FooCanaryStep)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooCanaryStep_To_v1alpha1_FooCanaryStep(a.(*samplecontroller.FooCanaryStep), b.(*FooCanaryStep), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooCanaryStrategy)(nil), (*samplecontroller.FooCanaryStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooCanaryStrategy_To_samplecontroller_FooCanaryStrategy(a.(*FooCanaryStrategy), b.(*samplecontroller.FooCanaryStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooCanaryStrategy)(nil), (*FooCanaryStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooCanaryStrategy_To_v1alpha1_FooCanaryStrategy(a.(*samplecontroller.FooCanaryStrategy), b.(*FooCanaryStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooDisruptionBudget)(nil), (*samplecontroller.FooDisruptionBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooDisruptionBudget_To_samplecontroller_FooDisruptionBudget(a.(*FooDisruptionBudget), b.(*samplecontroller.FooDisruptionBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooDisruptionBudget)(nil), (*FooDisruptionBudget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooDisruptionBudget_To_v1alpha1_FooDisruptionBudget(a.(*samplecontroller.FooDisruptionBudget), b.(*FooDisruptionBudget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooDisruptionBudgetStatus)(nil), (*samplecontroller.FooDisruptionBudgetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooDisruptionBudgetStatus_To_samplecontroller_FooDisruptionBudgetStatus(a.(*FooDisruptionBudgetStatus), b.(*samplecontroller.FooDisruptionBudgetStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooDisruptionBudgetStatus)(nil), (*FooDisruptionBudgetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooDisruptionBudgetStatus_To_v1alpha1_FooDisruptionBudgetStatus(a.(*samplecontroller.FooDisruptionBudgetStatus), b.(*FooDisruptionBudgetStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooList)(nil), (*samplecontroller.FooList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooList_To_samplecontroller_FooList(a.(*FooList), b.(*samplecontroller.FooList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooList)(nil), (*FooList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooList_To_v1alpha1_FooList(a.(*samplecontroller.FooList), b.(*FooList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooRollback)(nil), (*samplecontroller.FooRollback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooRollback_To_samplecontroller_FooRollback(a.(*FooRollback), b.(*samplecontroller.FooRollback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooRollback)(nil), (*FooRollback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooRollback_To_v1alpha1_FooRollback(a.(*samplecontroller.FooRollback), b.(*FooRollback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooRolloutStatus)(nil), (*samplecontroller.FooRolloutStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooRolloutStatus_To_samplecontroller_FooRolloutStatus(a.(*FooRolloutStatus), b.(*samplecontroller.FooRolloutStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooRolloutStatus)(nil), (*FooRolloutStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooRolloutStatus_To_v1alpha1_FooRolloutStatus(a.(*samplecontroller.FooRolloutStatus), b.(*FooRolloutStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooService)(nil), (*samplecontroller.FooService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooService_To_samplecontroller_FooService(a.(*FooService), b.(*samplecontroller.FooService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooService)(nil), (*FooService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooService_To_v1alpha1_FooService(a.(*samplecontroller.FooService), b.(*FooService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooServiceStatus)(nil), (*samplecontroller.FooServiceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooServiceStatus_To_samplecontroller_FooServiceStatus(a.(*FooServiceStatus), b.(*samplecontroller.FooServiceStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooServiceStatus)(nil), (*FooServiceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooServiceStatus_To_v1alpha1_FooServiceStatus(a.(*samplecontroller.FooServiceStatus), b.(*FooServiceStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooStatus)(nil), (*samplecontroller.FooStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooStatus_To_samplecontroller_FooStatus(a.(*FooStatus), b.(*samplecontroller.FooStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooStatus)(nil), (*FooStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooStatus_To_v1alpha1_FooStatus(a.(*samplecontroller.FooStatus), b.(*FooStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FooStrategy)(nil), (*samplecontroller.FooStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooStrategy_To_samplecontroller_FooStrategy(a.(*FooStrategy), b.(*samplecontroller.FooStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*samplecontroller.FooStrategy)(nil), (*FooStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooStrategy_To_v1alpha1_FooStrategy(a.(*samplecontroller.FooStrategy), b.(*FooStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*samplecontroller.FooSpec)(nil), (*FooSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_samplecontroller_FooSpec_To_v1alpha1_FooSpec(a.(*samplecontroller.FooSpec), b.(*FooSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*FooSpec)(nil), (*samplecontroller.FooSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FooSpec_To_samplecontroller_FooSpec(a.(*FooSpec), b.(*samplecontroller.FooSpec), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_Foo_To_samplecontroller_Foo(in *Foo, out *samplecontroller.Foo, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_FooSpec_To_samplecontroller_FooSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_FooStatus_To_samplecontroller_FooStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_Foo_To_samplecontroller_Foo is an autogenerated conversion function.
+func Convert_v1alpha1_Foo_To_samplecontroller_Foo(in *Foo, out *samplecontroller.Foo, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Foo_To_samplecontroller_Foo(in, out, s)
+}
+
+func autoConvert_samplecontroller_Foo_To_v1alpha1_Foo(in *samplecontroller.Foo, out *Foo, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_samplecontroller_FooSpec_To_v1alpha1_FooSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_samplecontroller_FooStatus_To_v1alpha1_FooStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_samplecontroller_Foo_To_v1alpha1_Foo is an autogenerated conversion function.
+func Convert_samplecontroller_Foo_To_v1alpha1_Foo(in *samplecontroller.Foo, out *Foo, s conversion.Scope) error {
+	return autoConvert_samplecontroller_Foo_To_v1alpha1_Foo(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooAutoscaling_To_samplecontroller_FooAutoscaling(in *FooAutoscaling, out *samplecontroller.FooAutoscaling, s conversion.Scope) error {
+	out.MinReplicas = (*int32)(unsafe.Pointer(in.MinReplicas))
+	out.MaxReplicas = in.MaxReplicas
+	out.Metrics = *(*[]v2.MetricSpec)(unsafe.Pointer(&in.Metrics))
+	return nil
+}
+
+// Convert_v1alpha1_FooAutoscaling_To_samplecontroller_FooAutoscaling is an autogenerated conversion function.
+func Convert_v1alpha1_FooAutoscaling_To_samplecontroller_FooAutoscaling(in *FooAutoscaling, out *samplecontroller.FooAutoscaling, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooAutoscaling_To_samplecontroller_FooAutoscaling(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooAutoscaling_To_v1alpha1_FooAutoscaling(in *samplecontroller.FooAutoscaling, out *FooAutoscaling, s conversion.Scope) error {
+	out.MinReplicas = (*int32)(unsafe.Pointer(in.MinReplicas))
+	out.MaxReplicas = in.MaxReplicas
+	out.Metrics = *(*[]v2.MetricSpec)(unsafe.Pointer(&in.Metrics))
+	return nil
+}
+
+// Convert_samplecontroller_FooAutoscaling_To_v1alpha1_FooAutoscaling is an autogenerated conversion function.
+func Convert_samplecontroller_FooAutoscaling_To_v1alpha1_FooAutoscaling(in *samplecontroller.FooAutoscaling, out *FooAutoscaling, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooAutoscaling_To_v1alpha1_FooAutoscaling(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooAutoscalingStatus_To_samplecontroller_FooAutoscalingStatus(in *FooAutoscalingStatus, out *samplecontroller.FooAutoscalingStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.CurrentReplicas = in.CurrentReplicas
+	out.DesiredReplicas = in.DesiredReplicas
+	return nil
+}
+
+// Convert_v1alpha1_FooAutoscalingStatus_To_samplecontroller_FooAutoscalingStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FooAutoscalingStatus_To_samplecontroller_FooAutoscalingStatus(in *FooAutoscalingStatus, out *samplecontroller.FooAutoscalingStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooAutoscalingStatus_To_samplecontroller_FooAutoscalingStatus(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooAutoscalingStatus_To_v1alpha1_FooAutoscalingStatus(in *samplecontroller.FooAutoscalingStatus, out *FooAutoscalingStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.CurrentReplicas = in.CurrentReplicas
+	out.DesiredReplicas = in.DesiredReplicas
+	return nil
+}
+
+// Convert_samplecontroller_FooAutoscalingStatus_To_v1alpha1_FooAutoscalingStatus is an autogenerated conversion function.
+func Convert_samplecontroller_FooAutoscalingStatus_To_v1alpha1_FooAutoscalingStatus(in *samplecontroller.FooAutoscalingStatus, out *FooAutoscalingStatus, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooAutoscalingStatus_To_v1alpha1_FooAutoscalingStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooCanaryPause_To_samplecontroller_FooCanaryPause(in *FooCanaryPause, out *samplecontroller.FooCanaryPause, s conversion.Scope) error {
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_v1alpha1_FooCanaryPause_To_samplecontroller_FooCanaryPause is an autogenerated conversion function.
+func Convert_v1alpha1_FooCanaryPause_To_samplecontroller_FooCanaryPause(in *FooCanaryPause, out *samplecontroller.FooCanaryPause, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooCanaryPause_To_samplecontroller_FooCanaryPause(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooCanaryPause_To_v1alpha1_FooCanaryPause(in *samplecontroller.FooCanaryPause, out *FooCanaryPause, s conversion.Scope) error {
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_samplecontroller_FooCanaryPause_To_v1alpha1_FooCanaryPause is an autogenerated conversion function.
+func Convert_samplecontroller_FooCanaryPause_To_v1alpha1_FooCanaryPause(in *samplecontroller.FooCanaryPause, out *FooCanaryPause, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooCanaryPause_To_v1alpha1_FooCanaryPause(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooCanaryStatus_To_samplecontroller_FooCanaryStatus(in *FooCanaryStatus, out *samplecontroller.FooCanaryStatus, s conversion.Scope) error {
+	out.DeploymentName = in.DeploymentName
+	out.TemplateHash = in.TemplateHash
+	out.Phase = samplecontroller.FooCanaryPhase(in.Phase)
+	out.Step = in.Step
+	out.Replicas = in.Replicas
+	out.AvailableReplicas = in.AvailableReplicas
+	out.PauseStartTime = (*v1.Time)(unsafe.Pointer(in.PauseStartTime))
+	return nil
+}
+
+// Convert_v1alpha1_FooCanaryStatus_To_samplecontroller_FooCanaryStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FooCanaryStatus_To_samplecontroller_FooCanaryStatus(in *FooCanaryStatus, out *samplecontroller.FooCanaryStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooCanaryStatus_To_samplecontroller_FooCanaryStatus(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooCanaryStatus_To_v1alpha1_FooCanaryStatus(in *samplecontroller.FooCanaryStatus, out *FooCanaryStatus, s conversion.Scope) error {
+	out.DeploymentName = in.DeploymentName
+	out.TemplateHash = in.TemplateHash
+	out.Phase = FooCanaryPhase(in.Phase)
+	out.Step = in.Step
+	out.Replicas = in.Replicas
+	out.AvailableReplicas = in.AvailableReplicas
+	out.PauseStartTime = (*v1.Time)(unsafe.Pointer(in.PauseStartTime))
+	return nil
+}
+
+// Convert_samplecontroller_FooCanaryStatus_To_v1alpha1_FooCanaryStatus is an autogenerated conversion function.
+func Convert_samplecontroller_FooCanaryStatus_To_v1alpha1_FooCanaryStatus(in *samplecontroller.FooCanaryStatus, out *FooCanaryStatus, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooCanaryStatus_To_v1alpha1_FooCanaryStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooCanaryStep_To_samplecontroller_FooCanaryStep(in *FooCanaryStep, out *samplecontroller.FooCanaryStep, s conversion.Scope) error {
+	out.Replicas = in.Replicas
+	out.Pause = (*samplecontroller.FooCanaryPause)(unsafe.Pointer(in.Pause))
+	return nil
+}
+
+// Convert_v1alpha1_FooCanaryStep_To_samplecontroller_FooCanaryStep is an autogenerated conversion function.
+func Convert_v1alpha1_FooCanaryStep_To_samplecontroller_FooCanaryStep(in *FooCanaryStep, out *samplecontroller.FooCanaryStep, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooCanaryStep_To_samplecontroller_FooCanaryStep(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooCanaryStep_To_v1alpha1_FooCanaryStep(in *samplecontroller.FooCanaryStep, out *FooCanaryStep, s conversion.Scope) error {
+	out.Replicas = in.Replicas
+	out.Pause = (*FooCanaryPause)(unsafe.Pointer(in.Pause))
+	return nil
+}
+
+// Convert_samplecontroller_FooCanaryStep_To_v1alpha1_FooCanaryStep is an autogenerated conversion function.
+func Convert_samplecontroller_FooCanaryStep_To_v1alpha1_FooCanaryStep(in *samplecontroller.FooCanaryStep, out *FooCanaryStep, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooCanaryStep_To_v1alpha1_FooCanaryStep(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooCanaryStrategy_To_samplecontroller_FooCanaryStrategy(in *FooCanaryStrategy, out *samplecontroller.FooCanaryStrategy, s conversion.Scope) error {
+	out.Steps = *(*[]samplecontroller.FooCanaryStep)(unsafe.Pointer(&in.Steps))
+	return nil
+}
+
+// Convert_v1alpha1_FooCanaryStrategy_To_samplecontroller_FooCanaryStrategy is an autogenerated conversion function.
+func Convert_v1alpha1_FooCanaryStrategy_To_samplecontroller_FooCanaryStrategy(in *FooCanaryStrategy, out *samplecontroller.FooCanaryStrategy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooCanaryStrategy_To_samplecontroller_FooCanaryStrategy(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooCanaryStrategy_To_v1alpha1_FooCanaryStrategy(in *samplecontroller.FooCanaryStrategy, out *FooCanaryStrategy, s conversion.Scope) error {
+	out.Steps = *(*[]FooCanaryStep)(unsafe.Pointer(&in.Steps))
+	return nil
+}
+
+// Convert_samplecontroller_FooCanaryStrategy_To_v1alpha1_FooCanaryStrategy is an autogenerated conversion function.
+func Convert_samplecontroller_FooCanaryStrategy_To_v1alpha1_FooCanaryStrategy(in *samplecontroller.FooCanaryStrategy, out *FooCanaryStrategy, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooCanaryStrategy_To_v1alpha1_FooCanaryStrategy(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooDisruptionBudget_To_samplecontroller_FooDisruptionBudget(in *FooDisruptionBudget, out *samplecontroller.FooDisruptionBudget, s conversion.Scope) error {
+	out.MinAvailable = (*intstr.IntOrString)(unsafe.Pointer(in.MinAvailable))
+	out.MaxUnavailable = (*intstr.IntOrString)(unsafe.Pointer(in.MaxUnavailable))
+	return nil
+}
+
+// Convert_v1alpha1_FooDisruptionBudget_To_samplecontroller_FooDisruptionBudget is an autogenerated conversion function.
+func Convert_v1alpha1_FooDisruptionBudget_To_samplecontroller_FooDisruptionBudget(in *FooDisruptionBudget, out *samplecontroller.FooDisruptionBudget, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooDisruptionBudget_To_samplecontroller_FooDisruptionBudget(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooDisruptionBudget_To_v1alpha1_FooDisruptionBudget(in *samplecontroller.FooDisruptionBudget, out *FooDisruptionBudget, s conversion.Scope) error {
+	out.MinAvailable = (*intstr.IntOrString)(unsafe.Pointer(in.MinAvailable))
+	out.MaxUnavailable = (*intstr.IntOrString)(unsafe.Pointer(in.MaxUnavailable))
+	return nil
+}
+
+// Convert_samplecontroller_FooDisruptionBudget_To_v1alpha1_FooDisruptionBudget is an autogenerated conversion function.
+func Convert_samplecontroller_FooDisruptionBudget_To_v1alpha1_FooDisruptionBudget(in *samplecontroller.FooDisruptionBudget, out *FooDisruptionBudget, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooDisruptionBudget_To_v1alpha1_FooDisruptionBudget(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooDisruptionBudgetStatus_To_samplecontroller_FooDisruptionBudgetStatus(in *FooDisruptionBudgetStatus, out *samplecontroller.FooDisruptionBudgetStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DisruptionsAllowed = in.DisruptionsAllowed
+	out.CurrentHealthy = in.CurrentHealthy
+	out.DesiredHealthy = in.DesiredHealthy
+	return nil
+}
+
+// Convert_v1alpha1_FooDisruptionBudgetStatus_To_samplecontroller_FooDisruptionBudgetStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FooDisruptionBudgetStatus_To_samplecontroller_FooDisruptionBudgetStatus(in *FooDisruptionBudgetStatus, out *samplecontroller.FooDisruptionBudgetStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooDisruptionBudgetStatus_To_samplecontroller_FooDisruptionBudgetStatus(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooDisruptionBudgetStatus_To_v1alpha1_FooDisruptionBudgetStatus(in *samplecontroller.FooDisruptionBudgetStatus, out *FooDisruptionBudgetStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DisruptionsAllowed = in.DisruptionsAllowed
+	out.CurrentHealthy = in.CurrentHealthy
+	out.DesiredHealthy = in.DesiredHealthy
+	return nil
+}
+
+// Convert_samplecontroller_FooDisruptionBudgetStatus_To_v1alpha1_FooDisruptionBudgetStatus is an autogenerated conversion function.
+func Convert_samplecontroller_FooDisruptionBudgetStatus_To_v1alpha1_FooDisruptionBudgetStatus(in *samplecontroller.FooDisruptionBudgetStatus, out *FooDisruptionBudgetStatus, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooDisruptionBudgetStatus_To_v1alpha1_FooDisruptionBudgetStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooList_To_samplecontroller_FooList(in *FooList, out *samplecontroller.FooList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]samplecontroller.Foo, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_Foo_To_samplecontroller_Foo(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_FooList_To_samplecontroller_FooList is an autogenerated conversion function.
+func Convert_v1alpha1_FooList_To_samplecontroller_FooList(in *FooList, out *samplecontroller.FooList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooList_To_samplecontroller_FooList(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooList_To_v1alpha1_FooList(in *samplecontroller.FooList, out *FooList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Foo, len(*in))
+		for i := range *in {
+			if err := Convert_samplecontroller_Foo_To_v1alpha1_Foo(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_samplecontroller_FooList_To_v1alpha1_FooList is an autogenerated conversion function.
+func Convert_samplecontroller_FooList_To_v1alpha1_FooList(in *samplecontroller.FooList, out *FooList, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooList_To_v1alpha1_FooList(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooRollback_To_samplecontroller_FooRollback(in *FooRollback, out *samplecontroller.FooRollback, s conversion.Scope) error {
+	out.Automatic = in.Automatic
+	return nil
+}
+
+// Convert_v1alpha1_FooRollback_To_samplecontroller_FooRollback is an autogenerated conversion function.
+func Convert_v1alpha1_FooRollback_To_samplecontroller_FooRollback(in *FooRollback, out *samplecontroller.FooRollback, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooRollback_To_samplecontroller_FooRollback(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooRollback_To_v1alpha1_FooRollback(in *samplecontroller.FooRollback, out *FooRollback, s conversion.Scope) error {
+	out.Automatic = in.Automatic
+	return nil
+}
+
+// Convert_samplecontroller_FooRollback_To_v1alpha1_FooRollback is an autogenerated conversion function.
+func Convert_samplecontroller_FooRollback_To_v1alpha1_FooRollback(in *samplecontroller.FooRollback, out *FooRollback, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooRollback_To_v1alpha1_FooRollback(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooRolloutStatus_To_samplecontroller_FooRolloutStatus(in *FooRolloutStatus, out *samplecontroller.FooRolloutStatus, s conversion.Scope) error {
+	out.LastGoodRevision = in.LastGoodRevision
+	out.LastGoodTemplateHash = in.LastGoodTemplateHash
+	out.LastGoodTemplate = (*corev1.PodTemplateSpec)(unsafe.Pointer(in.LastGoodTemplate))
+	out.FailedRevision = in.FailedRevision
+	out.FailedTemplateHash = in.FailedTemplateHash
+	return nil
+}
+
+// Convert_v1alpha1_FooRolloutStatus_To_samplecontroller_FooRolloutStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FooRolloutStatus_To_samplecontroller_FooRolloutStatus(in *FooRolloutStatus, out *samplecontroller.FooRolloutStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooRolloutStatus_To_samplecontroller_FooRolloutStatus(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooRolloutStatus_To_v1alpha1_FooRolloutStatus(in *samplecontroller.FooRolloutStatus, out *FooRolloutStatus, s conversion.Scope) error {
+	out.LastGoodRevision = in.LastGoodRevision
+	out.LastGoodTemplateHash = in.LastGoodTemplateHash
+	out.LastGoodTemplate = (*corev1.PodTemplateSpec)(unsafe.Pointer(in.LastGoodTemplate))
+	out.FailedRevision = in.FailedRevision
+	out.FailedTemplateHash = in.FailedTemplateHash
+	return nil
+}
+
+// Convert_samplecontroller_FooRolloutStatus_To_v1alpha1_FooRolloutStatus is an autogenerated conversion function.
+func Convert_samplecontroller_FooRolloutStatus_To_v1alpha1_FooRolloutStatus(in *samplecontroller.FooRolloutStatus, out *FooRolloutStatus, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooRolloutStatus_To_v1alpha1_FooRolloutStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooService_To_samplecontroller_FooService(in *FooService, out *samplecontroller.FooService, s conversion.Scope) error {
+	out.Type = corev1.ServiceType(in.Type)
+	out.Ports = *(*[]corev1.ServicePort)(unsafe.Pointer(&in.Ports))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_v1alpha1_FooService_To_samplecontroller_FooService is an autogenerated conversion function.
+func Convert_v1alpha1_FooService_To_samplecontroller_FooService(in *FooService, out *samplecontroller.FooService, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooService_To_samplecontroller_FooService(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooService_To_v1alpha1_FooService(in *samplecontroller.FooService, out *FooService, s conversion.Scope) error {
+	out.Type = corev1.ServiceType(in.Type)
+	out.Ports = *(*[]corev1.ServicePort)(unsafe.Pointer(&in.Ports))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_samplecontroller_FooService_To_v1alpha1_FooService is an autogenerated conversion function.
+func Convert_samplecontroller_FooService_To_v1alpha1_FooService(in *samplecontroller.FooService, out *FooService, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooService_To_v1alpha1_FooService(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooServiceStatus_To_samplecontroller_FooServiceStatus(in *FooServiceStatus, out *samplecontroller.FooServiceStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ClusterIP = in.ClusterIP
+	out.Ports = *(*[]corev1.ServicePort)(unsafe.Pointer(&in.Ports))
+	return nil
+}
+
+// Convert_v1alpha1_FooServiceStatus_To_samplecontroller_FooServiceStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FooServiceStatus_To_samplecontroller_FooServiceStatus(in *FooServiceStatus, out *samplecontroller.FooServiceStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooServiceStatus_To_samplecontroller_FooServiceStatus(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooServiceStatus_To_v1alpha1_FooServiceStatus(in *samplecontroller.FooServiceStatus, out *FooServiceStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ClusterIP = in.ClusterIP
+	out.Ports = *(*[]corev1.ServicePort)(unsafe.Pointer(&in.Ports))
+	return nil
+}
+
+// Convert_samplecontroller_FooServiceStatus_To_v1alpha1_FooServiceStatus is an autogenerated conversion function.
+func Convert_samplecontroller_FooServiceStatus_To_v1alpha1_FooServiceStatus(in *samplecontroller.FooServiceStatus, out *FooServiceStatus, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooServiceStatus_To_v1alpha1_FooServiceStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooSpec_To_samplecontroller_FooSpec(in *FooSpec, out *samplecontroller.FooSpec, s conversion.Scope) error {
+	// WARNING: in.DeploymentName requires manual conversion: does not exist in peer-type
+	// WARNING: in.Replicas requires manual conversion: does not exist in peer-type
+	out.Template = in.Template
+	out.DeletionPolicy = samplecontroller.DeletionPolicy(in.DeletionPolicy)
+	out.Service = (*samplecontroller.FooService)(unsafe.Pointer(in.Service))
+	out.Suspend = (*bool)(unsafe.Pointer(in.Suspend))
+	out.DisruptionBudget = (*samplecontroller.FooDisruptionBudget)(unsafe.Pointer(in.DisruptionBudget))
+	out.Autoscaling = (*samplecontroller.FooAutoscaling)(unsafe.Pointer(in.Autoscaling))
+	out.Rollback = (*samplecontroller.FooRollback)(unsafe.Pointer(in.Rollback))
+	out.Strategy = (*samplecontroller.FooStrategy)(unsafe.Pointer(in.Strategy))
+	return nil
+}
+
+func autoConvert_samplecontroller_FooSpec_To_v1alpha1_FooSpec(in *samplecontroller.FooSpec, out *FooSpec, s conversion.Scope) error {
+	// WARNING: in.Deployment requires manual conversion: does not exist in peer-type
+	out.Template = in.Template
+	out.DeletionPolicy = DeletionPolicy(in.DeletionPolicy)
+	out.Service = (*FooService)(unsafe.Pointer(in.Service))
+	out.Suspend = (*bool)(unsafe.Pointer(in.Suspend))
+	out.DisruptionBudget = (*FooDisruptionBudget)(unsafe.Pointer(in.DisruptionBudget))
+	out.Autoscaling = (*FooAutoscaling)(unsafe.Pointer(in.Autoscaling))
+	out.Rollback = (*FooRollback)(unsafe.Pointer(in.Rollback))
+	out.Strategy = (*FooStrategy)(unsafe.Pointer(in.Strategy))
+	return nil
+}
+
+func autoConvert_v1alpha1_FooStatus_To_samplecontroller_FooStatus(in *FooStatus, out *samplecontroller.FooStatus, s conversion.Scope) error {
+	out.AvailableReplicas = in.AvailableReplicas
+	out.DeploymentName = in.DeploymentName
+	out.Replicas = in.Replicas
+	out.Selector = in.Selector
+	out.ReadyReplicas = in.ReadyReplicas
+	out.UpdatedReplicas = in.UpdatedReplicas
+	out.ObservedGeneration = in.ObservedGeneration
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Service = (*samplecontroller.FooServiceStatus)(unsafe.Pointer(in.Service))
+	out.DisruptionBudget = (*samplecontroller.FooDisruptionBudgetStatus)(unsafe.Pointer(in.DisruptionBudget))
+	out.Autoscaling = (*samplecontroller.FooAutoscalingStatus)(unsafe.Pointer(in.Autoscaling))
+	out.Rollout = (*samplecontroller.FooRolloutStatus)(unsafe.Pointer(in.Rollout))
+	out.Canary = (*samplecontroller.FooCanaryStatus)(unsafe.Pointer(in.Canary))
+	return nil
+}
+
+// Convert_v1alpha1_FooStatus_To_samplecontroller_FooStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FooStatus_To_samplecontroller_FooStatus(in *FooStatus, out *samplecontroller.FooStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooStatus_To_samplecontroller_FooStatus(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooStatus_To_v1alpha1_FooStatus(in *samplecontroller.FooStatus, out *FooStatus, s conversion.Scope) error {
+	out.DeploymentName = in.DeploymentName
+	out.Replicas = in.Replicas
+	out.Selector = in.Selector
+	out.AvailableReplicas = in.AvailableReplicas
+	out.ReadyReplicas = in.ReadyReplicas
+	out.UpdatedReplicas = in.UpdatedReplicas
+	out.ObservedGeneration = in.ObservedGeneration
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Service = (*FooServiceStatus)(unsafe.Pointer(in.Service))
+	out.DisruptionBudget = (*FooDisruptionBudgetStatus)(unsafe.Pointer(in.DisruptionBudget))
+	out.Autoscaling = (*FooAutoscalingStatus)(unsafe.Pointer(in.Autoscaling))
+	out.Rollout = (*FooRolloutStatus)(unsafe.Pointer(in.Rollout))
+	out.Canary = (*FooCanaryStatus)(unsafe.Pointer(in.Canary))
+	return nil
+}
+
+// Convert_samplecontroller_FooStatus_To_v1alpha1_FooStatus is an autogenerated conversion function.
+func Convert_samplecontroller_FooStatus_To_v1alpha1_FooStatus(in *samplecontroller.FooStatus, out *FooStatus, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooStatus_To_v1alpha1_FooStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FooStrategy_To_samplecontroller_FooStrategy(in *FooStrategy, out *samplecontroller.FooStrategy, s conversion.Scope) error {
+	out.Canary = (*samplecontroller.FooCanaryStrategy)(unsafe.Pointer(in.Canary))
+	return nil
+}
+
+// Convert_v1alpha1_FooStrategy_To_samplecontroller_FooStrategy is an autogenerated conversion function.
+func Convert_v1alpha1_FooStrategy_To_samplecontroller_FooStrategy(in *FooStrategy, out *samplecontroller.FooStrategy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FooStrategy_To_samplecontroller_FooStrategy(in, out, s)
+}
+
+func autoConvert_samplecontroller_FooStrategy_To_v1alpha1_FooStrategy(in *samplecontroller.FooStrategy, out *FooStrategy, s conversion.Scope) error {
+	out.Canary = (*FooCanaryStrategy)(unsafe.Pointer(in.Canary))
+	return nil
+}
+
+// Convert_samplecontroller_FooStrategy_To_v1alpha1_FooStrategy is an autogenerated conversion function.
+func Convert_samplecontroller_FooStrategy_To_v1alpha1_FooStrategy(in *samplecontroller.FooStrategy, out *FooStrategy, s conversion.Scope) error {
+	return autoConvert_samplecontroller_FooStrategy_To_v1alpha1_FooStrategy(in, out, s)
+}
@@ -22,7 +22,11 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -31,7 +35,7 @@ func (in *Foo) DeepCopyInto(out *Foo) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -53,6 +57,178 @@ func (in *Foo) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooAutoscaling) DeepCopyInto(out *FooAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooAutoscaling.
+func (in *FooAutoscaling) DeepCopy() *FooAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(FooAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooAutoscalingStatus) DeepCopyInto(out *FooAutoscalingStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooAutoscalingStatus.
+func (in *FooAutoscalingStatus) DeepCopy() *FooAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FooAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooCanaryPause) DeepCopyInto(out *FooCanaryPause) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooCanaryPause.
+func (in *FooCanaryPause) DeepCopy() *FooCanaryPause {
+	if in == nil {
+		return nil
+	}
+	out := new(FooCanaryPause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooCanaryStatus) DeepCopyInto(out *FooCanaryStatus) {
+	*out = *in
+	if in.PauseStartTime != nil {
+		in, out := &in.PauseStartTime, &out.PauseStartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooCanaryStatus.
+func (in *FooCanaryStatus) DeepCopy() *FooCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FooCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooCanaryStep) DeepCopyInto(out *FooCanaryStep) {
+	*out = *in
+	out.Replicas = in.Replicas
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(FooCanaryPause)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooCanaryStep.
+func (in *FooCanaryStep) DeepCopy() *FooCanaryStep {
+	if in == nil {
+		return nil
+	}
+	out := new(FooCanaryStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooCanaryStrategy) DeepCopyInto(out *FooCanaryStrategy) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]FooCanaryStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooCanaryStrategy.
+func (in *FooCanaryStrategy) DeepCopy() *FooCanaryStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(FooCanaryStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooDisruptionBudget) DeepCopyInto(out *FooDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooDisruptionBudget.
+func (in *FooDisruptionBudget) DeepCopy() *FooDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(FooDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooDisruptionBudgetStatus) DeepCopyInto(out *FooDisruptionBudgetStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooDisruptionBudgetStatus.
+func (in *FooDisruptionBudgetStatus) DeepCopy() *FooDisruptionBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FooDisruptionBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FooList) DeepCopyInto(out *FooList) {
 	*out = *in
@@ -86,6 +262,96 @@ func (in *FooList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooRollback) DeepCopyInto(out *FooRollback) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooRollback.
+func (in *FooRollback) DeepCopy() *FooRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(FooRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooRolloutStatus) DeepCopyInto(out *FooRolloutStatus) {
+	*out = *in
+	if in.LastGoodTemplate != nil {
+		in, out := &in.LastGoodTemplate, &out.LastGoodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooRolloutStatus.
+func (in *FooRolloutStatus) DeepCopy() *FooRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FooRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooService) DeepCopyInto(out *FooService) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooService.
+func (in *FooService) DeepCopy() *FooService {
+	if in == nil {
+		return nil
+	}
+	out := new(FooService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooServiceStatus) DeepCopyInto(out *FooServiceStatus) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooServiceStatus.
+func (in *FooServiceStatus) DeepCopy() *FooServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FooServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FooSpec) DeepCopyInto(out *FooSpec) {
 	*out = *in
@@ -94,6 +360,37 @@ func (in *FooSpec) DeepCopyInto(out *FooSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(FooService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(FooDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(FooAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(FooRollback)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(FooStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -110,6 +407,42 @@ func (in *FooSpec) DeepCopy() *FooSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FooStatus) DeepCopyInto(out *FooStatus) {
 	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(FooServiceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(FooDisruptionBudgetStatus)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(FooAutoscalingStatus)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(FooRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(FooCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -122,3 +455,24 @@ func (in *FooStatus) DeepCopy() *FooStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FooStrategy) DeepCopyInto(out *FooStrategy) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(FooCanaryStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FooStrategy.
+func (in *FooStrategy) DeepCopy() *FooStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(FooStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,425 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +genclient
+// +genclient:method=GetScale,verb=get,subresource=scale,result=k8s.io/api/autoscaling/v1.Scale
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=k8s.io/api/autoscaling/v1.Scale,result=k8s.io/api/autoscaling/v1.Scale
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Foo is a specification for a Foo resource
+type Foo struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FooSpec   `json:"spec"`
+	Status FooStatus `json:"status"`
+}
+
+// FooSpec is the spec for a Foo resource
+type FooSpec struct {
+	// Deployment configures the Deployment managed for this Foo.
+	Deployment FooDeployment `json:"deployment"`
+
+	// Template describes the pods that will be created for this Foo. The
+	// controller adds its own ownership labels on top of the labels given
+	// here, so they must not be relied upon to select other workloads.
+	// +optional
+	Template corev1.PodTemplateSpec `json:"template,omitempty"`
+
+	// DeletionPolicy decides what happens to the Deployment and the other
+	// objects owned by this Foo when the Foo is deleted. Defaults to Delete.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Service, if set, makes the controller manage a Service named after the
+	// Foo that selects its pods. Removing it deletes the Service.
+	// +optional
+	Service *FooService `json:"service,omitempty"`
+
+	// Suspend, if true, stops the controller from changing the Deployment
+	// and the other objects owned by this Foo, e.g. so that a manual change
+	// made during an incident is not reverted. The status is still updated.
+	// The samplecontroller.k8s.io/paused annotation has the same effect. Defaults to false.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// DisruptionBudget, if set, makes the controller manage a
+	// PodDisruptionBudget named after the Foo that limits how many of its
+	// pods voluntary disruptions, like node drains, may evict at once.
+	// Removing it deletes the PodDisruptionBudget.
+	// +optional
+	DisruptionBudget *FooDisruptionBudget `json:"disruptionBudget,omitempty"`
+
+	// Autoscaling, if set, makes the controller manage a
+	// HorizontalPodAutoscaler named after the Foo that scales its Deployment.
+	// While it is set the replicas of the Foo are not enforced on the
+	// Deployment. Removing it deletes the HorizontalPodAutoscaler.
+	// +optional
+	Autoscaling *FooAutoscaling `json:"autoscaling,omitempty"`
+
+	// Rollback configures how rollouts of the Deployment that fail to make
+	// progress are handled.
+	// +optional
+	Rollback *FooRollback `json:"rollback,omitempty"`
+
+	// Strategy configures how changes of the template are rolled out. By
+	// default the Deployment replaces all pods with a rolling update.
+	// +optional
+	Strategy *FooStrategy `json:"strategy,omitempty"`
+}
+
+// FooStrategy describes how changes of the template of a Foo are rolled out.
+type FooStrategy struct {
+	// Canary, if set, rolls a changed template out to a second Deployment
+	// that runs next to the Deployment of the Foo, moving through Steps
+	// before the change is promoted to the Deployment of the Foo.
+	// +optional
+	Canary *FooCanaryStrategy `json:"canary,omitempty"`
+}
+
+// FooCanaryStrategy describes the steps of a canary rollout.
+type FooCanaryStrategy struct {
+	// Steps are the stages of the canary rollout, in order. The change is
+	// promoted once the last step is complete.
+	// +listType=atomic
+	Steps []FooCanaryStep `json:"steps"`
+}
+
+// FooCanaryStep is a single step of a canary rollout.
+type FooCanaryStep struct {
+	// Replicas is the number of canary replicas, or their percentage of
+	// spec.deployment.replicas, run during this step. The Deployment of the Foo is
+	// scaled down by as many replicas.
+	Replicas intstr.IntOrString `json:"replicas"`
+
+	// Pause, if set, holds the rollout at this step once the canary
+	// replicas are available.
+	// +optional
+	Pause *FooCanaryPause `json:"pause,omitempty"`
+}
+
+// FooCanaryPause holds a canary rollout at a step.
+type FooCanaryPause struct {
+	// Duration is how long the rollout is held. Without a duration it is
+	// held until it is promoted with the
+	// samplecontroller.k8s.io/promote-canary annotation.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// FooRollback describes how failed rollouts of a Foo are handled.
+type FooRollback struct {
+	// Automatic, if true, makes the controller restore the last pod template
+	// that was rolled out completely when the Deployment exceeds its
+	// progress deadline with the current one. The previous template is kept
+	// until the template of the Foo changes again. Defaults to false.
+	// +optional
+	Automatic bool `json:"automatic,omitempty"`
+}
+
+// FooAutoscaling describes the HorizontalPodAutoscaler owned by a Foo.
+type FooAutoscaling struct {
+	// MinReplicas is the lower limit for the number of replicas the
+	// autoscaler may scale the Deployment down to. Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas the
+	// autoscaler may scale the Deployment up to.
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Metrics are the metrics, like the CPU or memory utilization of the
+	// pods, used to calculate the desired replica count. Defaults to 80%
+	// average CPU utilization when empty.
+	// +optional
+	// +listType=atomic
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+// FooDisruptionBudget describes the PodDisruptionBudget owned by a Foo.
+// Exactly one of MinAvailable and MaxUnavailable must be set.
+type FooDisruptionBudget struct {
+	// MinAvailable is the number or percentage of the Foo's pods that must
+	// stay available during an eviction.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of the Foo's pods that may
+	// be unavailable after an eviction.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// FooService describes the Service owned by a Foo
+type FooService struct {
+	// Type is the type of the Service. Defaults to ClusterIP.
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Ports are the ports exposed by the Service.
+	// +listType=map
+	// +listMapKey=port
+	// +listMapKey=protocol
+	Ports []corev1.ServicePort `json:"ports"`
+
+	// Annotations are set on the Service, e.g. to configure a load balancer.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// FooDeployment describes the Deployment owned by a Foo
+type FooDeployment struct {
+	// Name is the name of the Deployment.
+	Name string `json:"name"`
+	// Replicas is the desired number of pods.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// DeletionPolicy describes how the objects owned by a Foo are treated when
+// the Foo is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the owned objects together with the Foo.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan releases the owned objects by removing the Foo's
+	// owner reference, leaving them running on their own.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyRetain releases the owned objects like Orphan, but marks
+	// them so that a Foo re-created with the same name adopts them again.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// FooStatus is the status for a Foo resource
+type FooStatus struct {
+	// AvailableReplicas is the number of available pods of the owned
+	// Deployment.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// DeploymentName is the name of the Deployment the controller currently
+	// runs for the Foo. Deployments left behind by a rename of the Foo's
+	// Deployment are removed once this one is available.
+	// +optional
+	DeploymentName string `json:"deploymentName,omitempty"`
+
+	// Replicas is the total number of pods of the owned Deployment. It is
+	// reported through the scale subresource.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Selector is the label selector of the Foo's pods in string form. It is
+	// reported through the scale subresource so the HorizontalPodAutoscaler
+	// can find the pods.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// ReadyReplicas is the number of ready pods of the owned Deployment.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// UpdatedReplicas is the number of pods of the owned Deployment that
+	// already run the current pod template.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the Foo observed by
+	// the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the last time a sync of the Foo changed its status.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Conditions describe the current state of the Foo. Known condition types
+	// are Ready, Progressing, Degraded, ResourceConflict, FieldConflict,
+	// MissingDependency and Suspended.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Service reports the Service owned by the Foo, if any.
+	// +optional
+	Service *FooServiceStatus `json:"service,omitempty"`
+
+	// DisruptionBudget reports the PodDisruptionBudget owned by the Foo, if
+	// any.
+	// +optional
+	DisruptionBudget *FooDisruptionBudgetStatus `json:"disruptionBudget,omitempty"`
+
+	// Autoscaling reports the HorizontalPodAutoscaler owned by the Foo, if
+	// any.
+	// +optional
+	Autoscaling *FooAutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// Rollout reports the last pod template that was rolled out completely
+	// and the last rollout that failed, if any.
+	// +optional
+	Rollout *FooRolloutStatus `json:"rollout,omitempty"`
+
+	// Canary reports the last canary rollout of the Foo, if any.
+	// +optional
+	Canary *FooCanaryStatus `json:"canary,omitempty"`
+}
+
+// FooServiceStatus describes the Service owned by a Foo as it was last seen
+// by the controller.
+type FooServiceStatus struct {
+	// Name is the name of the Service.
+	Name string `json:"name"`
+
+	// ClusterIP is the IP address allocated to the Service.
+	// +optional
+	ClusterIP string `json:"clusterIP,omitempty"`
+
+	// Ports are the ports of the Service, including the node ports
+	// allocated to it.
+	// +optional
+	// +listType=atomic
+	Ports []corev1.ServicePort `json:"ports,omitempty"`
+}
+
+// FooDisruptionBudgetStatus describes the PodDisruptionBudget owned by a Foo
+// as it was last seen by the controller.
+type FooDisruptionBudgetStatus struct {
+	// Name is the name of the PodDisruptionBudget.
+	Name string `json:"name"`
+
+	// DisruptionsAllowed is the number of the Foo's pods that may currently
+	// be evicted.
+	DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+
+	// CurrentHealthy is the number of healthy pods of the Foo.
+	// +optional
+	CurrentHealthy int32 `json:"currentHealthy,omitempty"`
+
+	// DesiredHealthy is the minimum number of healthy pods the
+	// PodDisruptionBudget requires.
+	// +optional
+	DesiredHealthy int32 `json:"desiredHealthy,omitempty"`
+}
+
+// FooAutoscalingStatus describes the HorizontalPodAutoscaler owned by a Foo
+// as it was last seen by the controller.
+type FooAutoscalingStatus struct {
+	// Name is the name of the HorizontalPodAutoscaler.
+	Name string `json:"name"`
+
+	// CurrentReplicas is the number of replicas of the Deployment last seen
+	// by the autoscaler.
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// DesiredReplicas is the number of replicas the autoscaler last
+	// calculated for the Deployment.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+}
+
+// FooRolloutStatus describes the rollouts of the Deployment owned by a Foo.
+type FooRolloutStatus struct {
+	// LastGoodRevision is the revision of the Deployment that last ran the
+	// pod template of the Foo on all of its replicas.
+	// +optional
+	LastGoodRevision string `json:"lastGoodRevision,omitempty"`
+
+	// LastGoodTemplateHash identifies LastGoodTemplate.
+	// +optional
+	LastGoodTemplateHash string `json:"lastGoodTemplateHash,omitempty"`
+
+	// LastGoodTemplate is the pod template of LastGoodRevision, as the
+	// controller set it on the Deployment. It is restored by automatic
+	// rollbacks.
+	// +optional
+	LastGoodTemplate *corev1.PodTemplateSpec `json:"lastGoodTemplate,omitempty"`
+
+	// FailedRevision is the revision of the Deployment whose rollout last
+	// exceeded its progress deadline and was rolled back.
+	// +optional
+	FailedRevision string `json:"failedRevision,omitempty"`
+
+	// FailedTemplateHash identifies the pod template of FailedRevision. The
+	// Foo stays rolled back while its template hashes to it.
+	// +optional
+	FailedTemplateHash string `json:"failedTemplateHash,omitempty"`
+}
+
+// FooCanaryPhase is the phase of a canary rollout.
+type FooCanaryPhase string
+
+const (
+	// FooCanaryProgressing means the canary replicas are scaled to the
+	// current step.
+	FooCanaryProgressing FooCanaryPhase = "Progressing"
+	// FooCanaryPaused means the rollout is held at the current step.
+	FooCanaryPaused FooCanaryPhase = "Paused"
+	// FooCanaryPromoted means the template was promoted to the Deployment of
+	// the Foo and the canary Deployment removed.
+	FooCanaryPromoted FooCanaryPhase = "Promoted"
+	// FooCanaryAborted means the canary Deployment was removed and the
+	// Deployment of the Foo keeps its previous template until the template
+	// of the Foo changes again.
+	FooCanaryAborted FooCanaryPhase = "Aborted"
+)
+
+// FooCanaryStatus describes the canary rollout of a Foo.
+type FooCanaryStatus struct {
+	// DeploymentName is the name of the canary Deployment.
+	DeploymentName string `json:"deploymentName"`
+
+	// TemplateHash identifies the pod template rolled out by the canary.
+	TemplateHash string `json:"templateHash"`
+
+	// Phase is the phase of the canary rollout.
+	Phase FooCanaryPhase `json:"phase"`
+
+	// Step is the index of the current step in spec.strategy.canary.steps.
+	// +optional
+	Step int32 `json:"step,omitempty"`
+
+	// Replicas is the number of canary replicas of the current step.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// AvailableReplicas is the number of available canary replicas.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// PauseStartTime is when the rollout was held at the current step.
+	// +optional
+	PauseStartTime *metav1.Time `json:"pauseStartTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FooList is a list of Foo resources
+type FooList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Foo `json:"items"`
+}
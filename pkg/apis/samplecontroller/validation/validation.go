@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates v1alpha1 Foos, the version stored and
+// reconciled by the sample controller.
+package validation
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+var supportedDeletionPolicies = sets.New(
+	v1alpha1.DeletionPolicyDelete,
+	v1alpha1.DeletionPolicyOrphan,
+	v1alpha1.DeletionPolicyRetain,
+)
+
+var supportedServiceTypes = sets.New(
+	corev1.ServiceTypeClusterIP,
+	corev1.ServiceTypeNodePort,
+	corev1.ServiceTypeLoadBalancer,
+)
+
+var supportedPortProtocols = sets.New(
+	corev1.ProtocolTCP,
+	corev1.ProtocolUDP,
+	corev1.ProtocolSCTP,
+)
+
+var supportedMetricSourceTypes = sets.New(
+	autoscalingv2.ObjectMetricSourceType,
+	autoscalingv2.PodsMetricSourceType,
+	autoscalingv2.ResourceMetricSourceType,
+	autoscalingv2.ContainerResourceMetricSourceType,
+	autoscalingv2.ExternalMetricSourceType,
+)
+
+// ValidateFoo tests that a Foo is well formed.
+func ValidateFoo(foo *v1alpha1.Foo) field.ErrorList {
+	return ValidateFooSpec(&foo.Spec, field.NewPath("spec"))
+}
+
+// ValidateFooSpec tests that the spec of a Foo is well formed.
+func ValidateFooSpec(spec *v1alpha1.FooSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.DeploymentName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("deploymentName"), ""))
+	} else {
+		for _, msg := range apimachineryvalidation.NameIsDNSSubdomain(spec.DeploymentName, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("deploymentName"), spec.DeploymentName, msg))
+		}
+	}
+
+	if spec.Replicas != nil {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateNonnegativeField(int64(*spec.Replicas), fldPath.Child("replicas"))...)
+	}
+
+	if spec.DeletionPolicy != "" && !supportedDeletionPolicies.Has(spec.DeletionPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("deletionPolicy"), spec.DeletionPolicy, sets.List(supportedDeletionPolicies)))
+	}
+
+	if spec.Service != nil {
+		allErrs = append(allErrs, validateFooService(spec.Service, fldPath.Child("service"))...)
+	}
+
+	if spec.DisruptionBudget != nil {
+		allErrs = append(allErrs, validateFooDisruptionBudget(spec.DisruptionBudget, fldPath.Child("disruptionBudget"))...)
+	}
+
+	if spec.Autoscaling != nil {
+		allErrs = append(allErrs, validateFooAutoscaling(spec.Autoscaling, fldPath.Child("autoscaling"))...)
+	}
+
+	if spec.Strategy != nil && spec.Strategy.Canary != nil {
+		allErrs = append(allErrs, validateFooCanaryStrategy(spec.Strategy.Canary, fldPath.Child("strategy", "canary"))...)
+	}
+
+	return allErrs
+}
+
+// validateFooService tests that the Service requested by a Foo is well
+// formed. The API server validates the Service itself when it is applied.
+func validateFooService(service *v1alpha1.FooService, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if service.Type != "" && !supportedServiceTypes.Has(service.Type) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), service.Type, sets.List(supportedServiceTypes)))
+	}
+
+	portsPath := fldPath.Child("ports")
+	if len(service.Ports) == 0 {
+		allErrs = append(allErrs, field.Required(portsPath, ""))
+	}
+	names := sets.New[string]()
+	for i, port := range service.Ports {
+		idxPath := portsPath.Index(i)
+		if port.Name != "" {
+			for _, msg := range validation.IsDNS1123Label(port.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), port.Name, msg))
+			}
+			if names.Has(port.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), port.Name))
+			}
+			names.Insert(port.Name)
+		} else if len(service.Ports) > 1 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must be set when there is more than one port"))
+		}
+		for _, msg := range validation.IsValidPortNum(int(port.Port)) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), port.Port, msg))
+		}
+		if port.Protocol != "" && !supportedPortProtocols.Has(port.Protocol) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("protocol"), port.Protocol, sets.List(supportedPortProtocols)))
+		}
+	}
+
+	allErrs = append(allErrs, apimachineryvalidation.ValidateAnnotations(service.Annotations, fldPath.Child("annotations"))...)
+
+	return allErrs
+}
+
+// ValidateFooUpdate tests that an update of a Foo is allowed. A spec that
+// does not change is not validated again, so Foos stored before a rule was
+// added can still be updated, for example to remove their finalizer.
+func ValidateFooUpdate(newFoo, oldFoo *v1alpha1.Foo) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if apiequality.Semantic.DeepEqual(newFoo.Spec, oldFoo.Spec) {
+		return allErrs
+	}
+
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, ValidateFooSpec(&newFoo.Spec, specPath)...)
+
+	// The deletion policy cannot change while the Foo carries the finalizer
+	// through which the controller applies it. The Deployment name is fixed
+	// once deletion started, as that Deployment is the one finalized.
+	if slices.Contains(oldFoo.Finalizers, v1alpha1.FooFinalizer) {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(newFoo.Spec.DeletionPolicy, oldFoo.Spec.DeletionPolicy, specPath.Child("deletionPolicy"))...)
+	}
+	if oldFoo.DeletionTimestamp != nil {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(newFoo.Spec.DeploymentName, oldFoo.Spec.DeploymentName, specPath.Child("deploymentName"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateDeploymentOwnership tests that the Deployment named by a Foo, if
+// it exists, can be run by the Foo: it must be controlled by the Foo or have
+// been retained by an earlier Foo of the same name, which the controller
+// adopts.
+func ValidateDeploymentOwnership(foo *v1alpha1.Foo, deployment *appsv1.Deployment) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if deployment == nil || metav1.IsControlledBy(deployment, foo) {
+		return allErrs
+	}
+	if metav1.GetControllerOf(deployment) == nil && deployment.Annotations[v1alpha1.RetainedFromAnnotation] == foo.Name {
+		return allErrs
+	}
+	return append(allErrs, field.Invalid(field.NewPath("spec", "deploymentName"), foo.Spec.DeploymentName,
+		"already used by a Deployment that is not controlled by this Foo"))
+}
+
+// validateFooDisruptionBudget tests that the PodDisruptionBudget requested by
+// a Foo sets exactly one of minAvailable and maxUnavailable, to a
+// non-negative number or a percentage.
+func validateFooDisruptionBudget(budget *v1alpha1.FooDisruptionBudget, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case budget.MinAvailable == nil && budget.MaxUnavailable == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "one of minAvailable and maxUnavailable must be set"))
+	case budget.MinAvailable != nil && budget.MaxUnavailable != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxUnavailable"), "may not be set together with minAvailable"))
+	}
+	if budget.MinAvailable != nil {
+		allErrs = append(allErrs, validateIntOrPercent(budget.MinAvailable, fldPath.Child("minAvailable"))...)
+	}
+	if budget.MaxUnavailable != nil {
+		allErrs = append(allErrs, validateIntOrPercent(budget.MaxUnavailable, fldPath.Child("maxUnavailable"))...)
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent tests that value is a non-negative number or a
+// percentage of at most 100%.
+func validateIntOrPercent(value *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	if value.Type == intstr.Int {
+		return apimachineryvalidation.ValidateNonnegativeField(int64(value.IntValue()), fldPath)
+	}
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsValidPercent(value.StrVal) {
+		allErrs = append(allErrs, field.Invalid(fldPath, value.StrVal, msg))
+	}
+	if len(allErrs) == 0 {
+		if percent, _ := strconv.Atoi(strings.TrimSuffix(value.StrVal, "%")); percent > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath, value.StrVal, "must not be greater than 100%"))
+		}
+	}
+	return allErrs
+}
+
+// validateFooAutoscaling tests that the HorizontalPodAutoscaler requested by
+// a Foo has a replica range of at least one replica and names the type of
+// each of its metrics. The API server validates the metrics themselves when
+// the HorizontalPodAutoscaler is applied.
+func validateFooAutoscaling(autoscaling *v1alpha1.FooAutoscaling, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if autoscaling.MaxReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), autoscaling.MaxReplicas, "must be greater than or equal to 1"))
+	}
+	if minReplicas := autoscaling.MinReplicas; minReplicas != nil {
+		if *minReplicas < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), *minReplicas, "must be greater than or equal to 1"))
+		} else if *minReplicas > autoscaling.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), *minReplicas, "must not be greater than maxReplicas"))
+		}
+	}
+
+	for i, metric := range autoscaling.Metrics {
+		if !supportedMetricSourceTypes.Has(metric.Type) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("metrics").Index(i).Child("type"), metric.Type, sets.List(supportedMetricSourceTypes)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateFooCanaryStrategy tests that a canary rollout has at least one
+// step, that each step runs at least one canary replica and that pauses are
+// not negative.
+func validateFooCanaryStrategy(canary *v1alpha1.FooCanaryStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	stepsPath := fldPath.Child("steps")
+	if len(canary.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(stepsPath, ""))
+	}
+	for i, step := range canary.Steps {
+		idxPath := stepsPath.Index(i)
+		replicasErrs := validateIntOrPercent(&step.Replicas, idxPath.Child("replicas"))
+		// Scaled against 100 replicas, a number stays as it is and a
+		// percentage becomes its value.
+		if scaled, _ := intstr.GetScaledValueFromIntOrPercent(&step.Replicas, 100, true); len(replicasErrs) == 0 && scaled == 0 {
+			replicasErrs = append(replicasErrs, field.Invalid(idxPath.Child("replicas"), step.Replicas.String(), "must be greater than zero"))
+		}
+		allErrs = append(allErrs, replicasErrs...)
+		if step.Pause != nil && step.Pause.Duration != nil && step.Pause.Duration.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("pause", "duration"), step.Pause.Duration.String(), "must not be negative"))
+		}
+	}
+
+	return allErrs
+}
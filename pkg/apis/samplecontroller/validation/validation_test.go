@@ -0,0 +1,312 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+func newFoo() *v1alpha1.Foo {
+	return &v1alpha1.Foo{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceDefault, UID: "foo-uid"},
+		Spec: v1alpha1.FooSpec{
+			DeploymentName: "test-deployment",
+			Replicas:       ptr.To[int32](1),
+		},
+	}
+}
+
+func TestValidateFoo(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mutate         func(foo *v1alpha1.Foo)
+		expectedFields []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(foo *v1alpha1.Foo) {},
+		},
+		{
+			name:           "empty deployment name",
+			mutate:         func(foo *v1alpha1.Foo) { foo.Spec.DeploymentName = "" },
+			expectedFields: []string{"spec.deploymentName"},
+		},
+		{
+			name:           "invalid deployment name",
+			mutate:         func(foo *v1alpha1.Foo) { foo.Spec.DeploymentName = "Not_A_Name" },
+			expectedFields: []string{"spec.deploymentName"},
+		},
+		{
+			name:           "negative replicas",
+			mutate:         func(foo *v1alpha1.Foo) { foo.Spec.Replicas = ptr.To[int32](-1) },
+			expectedFields: []string{"spec.replicas"},
+		},
+		{
+			name:           "unknown deletion policy",
+			mutate:         func(foo *v1alpha1.Foo) { foo.Spec.DeletionPolicy = "Keep" },
+			expectedFields: []string{"spec.deletionPolicy"},
+		},
+		{
+			name: "valid service",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Service = &v1alpha1.FooService{
+					Type:  corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "metrics", Port: 9090, Protocol: corev1.ProtocolTCP}},
+				}
+			},
+		},
+		{
+			name:           "service without ports",
+			mutate:         func(foo *v1alpha1.Foo) { foo.Spec.Service = &v1alpha1.FooService{Type: "Headless"} },
+			expectedFields: []string{"spec.service.type", "spec.service.ports"},
+		},
+		{
+			name: "invalid service ports",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Service = &v1alpha1.FooService{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 0}, {Name: "http", Port: 81, Protocol: "HTTP"}, {Port: 82}},
+				}
+			},
+			expectedFields: []string{"spec.service.ports[0].port", "spec.service.ports[1].name", "spec.service.ports[1].protocol", "spec.service.ports[2].name"},
+		},
+		{
+			name: "disruption budget",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.DisruptionBudget = &v1alpha1.FooDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromString("25%"))}
+			},
+		},
+		{
+			name:           "empty disruption budget",
+			mutate:         func(foo *v1alpha1.Foo) { foo.Spec.DisruptionBudget = &v1alpha1.FooDisruptionBudget{} },
+			expectedFields: []string{"spec.disruptionBudget"},
+		},
+		{
+			name: "invalid disruption budget",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.DisruptionBudget = &v1alpha1.FooDisruptionBudget{
+					MinAvailable:   ptr.To(intstr.FromInt32(-1)),
+					MaxUnavailable: ptr.To(intstr.FromString("150%")),
+				}
+			},
+			expectedFields: []string{"spec.disruptionBudget.maxUnavailable", "spec.disruptionBudget.minAvailable", "spec.disruptionBudget.maxUnavailable"},
+		},
+		{
+			name: "autoscaling",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Autoscaling = &v1alpha1.FooAutoscaling{
+					MinReplicas: ptr.To[int32](2),
+					MaxReplicas: 10,
+					Metrics: []autoscalingv2.MetricSpec{{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricSource{
+							Name:   corev1.ResourceMemory,
+							Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To[int32](70)},
+						},
+					}},
+				}
+			},
+		},
+		{
+			name: "invalid autoscaling",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Autoscaling = &v1alpha1.FooAutoscaling{
+					MinReplicas: ptr.To[int32](5),
+					MaxReplicas: 3,
+					Metrics:     []autoscalingv2.MetricSpec{{Type: "Memory"}},
+				}
+			},
+			expectedFields: []string{"spec.autoscaling.minReplicas", "spec.autoscaling.metrics[0].type"},
+		},
+		{
+			name: "canary strategy",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Strategy = &v1alpha1.FooStrategy{Canary: &v1alpha1.FooCanaryStrategy{
+					Steps: []v1alpha1.FooCanaryStep{
+						{Replicas: intstr.FromInt32(1), Pause: &v1alpha1.FooCanaryPause{Duration: &metav1.Duration{Duration: time.Minute}}},
+						{Replicas: intstr.FromString("50%"), Pause: &v1alpha1.FooCanaryPause{}},
+					},
+				}}
+			},
+		},
+		{
+			name: "invalid canary strategy",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Strategy = &v1alpha1.FooStrategy{Canary: &v1alpha1.FooCanaryStrategy{
+					Steps: []v1alpha1.FooCanaryStep{
+						{Replicas: intstr.FromString("0%")},
+						{Replicas: intstr.FromInt32(1), Pause: &v1alpha1.FooCanaryPause{Duration: &metav1.Duration{Duration: -time.Minute}}},
+					},
+				}}
+			},
+			expectedFields: []string{"spec.strategy.canary.steps[0].replicas", "spec.strategy.canary.steps[1].pause.duration"},
+		},
+		{
+			name: "canary strategy without steps",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Strategy = &v1alpha1.FooStrategy{Canary: &v1alpha1.FooCanaryStrategy{}}
+			},
+			expectedFields: []string{"spec.strategy.canary.steps"},
+		},
+		{
+			name: "no autoscaling replicas",
+			mutate: func(foo *v1alpha1.Foo) {
+				foo.Spec.Autoscaling = &v1alpha1.FooAutoscaling{MinReplicas: ptr.To[int32](0)}
+			},
+			expectedFields: []string{"spec.autoscaling.maxReplicas", "spec.autoscaling.minReplicas"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			foo := newFoo()
+			tc.mutate(foo)
+			checkErrorFields(t, ValidateFoo(foo), tc.expectedFields)
+		})
+	}
+}
+
+func TestValidateFooUpdate(t *testing.T) {
+	now := metav1.Now()
+	testCases := []struct {
+		name           string
+		mutateOld      func(foo *v1alpha1.Foo)
+		mutateNew      func(foo *v1alpha1.Foo)
+		expectedFields []string
+	}{
+		{
+			name:      "rename",
+			mutateOld: func(foo *v1alpha1.Foo) {},
+			mutateNew: func(foo *v1alpha1.Foo) { foo.Spec.DeploymentName = "renamed" },
+		},
+		{
+			name:      "unchanged invalid spec",
+			mutateOld: func(foo *v1alpha1.Foo) { foo.Spec.DeploymentName = "" },
+			mutateNew: func(foo *v1alpha1.Foo) {
+				foo.Spec.DeploymentName = ""
+				foo.Finalizers = nil
+			},
+		},
+		{
+			name:           "invalid change",
+			mutateOld:      func(foo *v1alpha1.Foo) {},
+			mutateNew:      func(foo *v1alpha1.Foo) { foo.Spec.DeploymentName = "" },
+			expectedFields: []string{"spec.deploymentName"},
+		},
+		{
+			name:      "policy without finalizer",
+			mutateOld: func(foo *v1alpha1.Foo) {},
+			mutateNew: func(foo *v1alpha1.Foo) { foo.Spec.DeletionPolicy = v1alpha1.DeletionPolicyRetain },
+		},
+		{
+			name:      "policy with finalizer",
+			mutateOld: func(foo *v1alpha1.Foo) { foo.Finalizers = []string{v1alpha1.FooFinalizer} },
+			mutateNew: func(foo *v1alpha1.Foo) {
+				foo.Finalizers = []string{v1alpha1.FooFinalizer}
+				foo.Spec.DeletionPolicy = v1alpha1.DeletionPolicyRetain
+			},
+			expectedFields: []string{"spec.deletionPolicy"},
+		},
+		{
+			name:      "scale while deleting",
+			mutateOld: func(foo *v1alpha1.Foo) { foo.DeletionTimestamp = &now },
+			mutateNew: func(foo *v1alpha1.Foo) {
+				foo.DeletionTimestamp = &now
+				foo.Spec.Replicas = ptr.To[int32](2)
+			},
+		},
+		{
+			name: "policy and rename while deleting",
+			mutateOld: func(foo *v1alpha1.Foo) {
+				foo.DeletionTimestamp = &now
+				foo.Finalizers = []string{v1alpha1.FooFinalizer}
+			},
+			mutateNew: func(foo *v1alpha1.Foo) {
+				foo.DeletionTimestamp = &now
+				foo.Finalizers = []string{v1alpha1.FooFinalizer}
+				foo.Spec.DeploymentName = "renamed"
+				foo.Spec.DeletionPolicy = v1alpha1.DeletionPolicyOrphan
+			},
+			expectedFields: []string{"spec.deletionPolicy", "spec.deploymentName"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldFoo, newFoo := newFoo(), newFoo()
+			tc.mutateOld(oldFoo)
+			tc.mutateNew(newFoo)
+			checkErrorFields(t, ValidateFooUpdate(newFoo, oldFoo), tc.expectedFields)
+		})
+	}
+}
+
+func TestValidateDeploymentOwnership(t *testing.T) {
+	foo := newFoo()
+	controlled := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:            "test-deployment",
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(foo, v1alpha1.SchemeGroupVersion.WithKind("Foo"))},
+	}}
+	other := newFoo()
+	other.UID = "other-uid"
+	foreign := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:            "test-deployment",
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(other, v1alpha1.SchemeGroupVersion.WithKind("Foo"))},
+	}}
+	retained := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:        "test-deployment",
+		Annotations: map[string]string{v1alpha1.RetainedFromAnnotation: foo.Name},
+	}}
+	unowned := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-deployment"}}
+
+	testCases := []struct {
+		name           string
+		deployment     *appsv1.Deployment
+		expectedFields []string
+	}{
+		{name: "missing"},
+		{name: "controlled", deployment: controlled},
+		{name: "retained", deployment: retained},
+		{name: "controlled by another Foo", deployment: foreign, expectedFields: []string{"spec.deploymentName"}},
+		{name: "not controlled", deployment: unowned, expectedFields: []string{"spec.deploymentName"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checkErrorFields(t, ValidateDeploymentOwnership(foo, tc.deployment), tc.expectedFields)
+		})
+	}
+}
+
+func checkErrorFields(t *testing.T, errs field.ErrorList, expected []string) {
+	t.Helper()
+	if len(errs) != len(expected) {
+		t.Fatalf("expected errors for %v, got %v", expected, errs)
+	}
+	for i := range errs {
+		if errs[i].Field != expected[i] {
+			t.Errorf("expected error %d for %s, got %v", i, expected[i], errs[i])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v2 "k8s.io/client-go/applyconfigurations/autoscaling/v2"
+)
+
+// FooAutoscalingApplyConfiguration represents a declarative configuration of the FooAutoscaling type for use
+// with apply.
+type FooAutoscalingApplyConfiguration struct {
+	MinReplicas *int32                            `json:"minReplicas,omitempty"`
+	MaxReplicas *int32                            `json:"maxReplicas,omitempty"`
+	Metrics     []v2.MetricSpecApplyConfiguration `json:"metrics,omitempty"`
+}
+
+// FooAutoscalingApplyConfiguration constructs a declarative configuration of the FooAutoscaling type for use with
+// apply.
+func FooAutoscaling() *FooAutoscalingApplyConfiguration {
+	return &FooAutoscalingApplyConfiguration{}
+}
+
+// WithMinReplicas sets the MinReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReplicas field is set to the value of the last call.
+func (b *FooAutoscalingApplyConfiguration) WithMinReplicas(value int32) *FooAutoscalingApplyConfiguration {
+	b.MinReplicas = &value
+	return b
+}
+
+// WithMaxReplicas sets the MaxReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxReplicas field is set to the value of the last call.
+func (b *FooAutoscalingApplyConfiguration) WithMaxReplicas(value int32) *FooAutoscalingApplyConfiguration {
+	b.MaxReplicas = &value
+	return b
+}
+
+// WithMetrics adds the given value to the Metrics field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Metrics field.
+func (b *FooAutoscalingApplyConfiguration) WithMetrics(values ...*v2.MetricSpecApplyConfiguration) *FooAutoscalingApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMetrics")
+		}
+		b.Metrics = append(b.Metrics, *values[i])
+	}
+	return b
+}
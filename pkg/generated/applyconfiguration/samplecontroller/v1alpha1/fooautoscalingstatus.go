@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// FooAutoscalingStatusApplyConfiguration represents a declarative configuration of the FooAutoscalingStatus type for use
+// with apply.
+type FooAutoscalingStatusApplyConfiguration struct {
+	Name            *string `json:"name,omitempty"`
+	CurrentReplicas *int32  `json:"currentReplicas,omitempty"`
+	DesiredReplicas *int32  `json:"desiredReplicas,omitempty"`
+}
+
+// FooAutoscalingStatusApplyConfiguration constructs a declarative configuration of the FooAutoscalingStatus type for use with
+// apply.
+func FooAutoscalingStatus() *FooAutoscalingStatusApplyConfiguration {
+	return &FooAutoscalingStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *FooAutoscalingStatusApplyConfiguration) WithName(value string) *FooAutoscalingStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCurrentReplicas sets the CurrentReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentReplicas field is set to the value of the last call.
+func (b *FooAutoscalingStatusApplyConfiguration) WithCurrentReplicas(value int32) *FooAutoscalingStatusApplyConfiguration {
+	b.CurrentReplicas = &value
+	return b
+}
+
+// WithDesiredReplicas sets the DesiredReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DesiredReplicas field is set to the value of the last call.
+func (b *FooAutoscalingStatusApplyConfiguration) WithDesiredReplicas(value int32) *FooAutoscalingStatusApplyConfiguration {
+	b.DesiredReplicas = &value
+	return b
+}
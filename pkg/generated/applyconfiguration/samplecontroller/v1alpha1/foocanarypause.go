@@ -0,0 +1,43 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FooCanaryPauseApplyConfiguration represents a declarative configuration of the FooCanaryPause type for use
+// with apply.
+type FooCanaryPauseApplyConfiguration struct {
+	Duration *v1.Duration `json:"duration,omitempty"`
+}
+
+// FooCanaryPauseApplyConfiguration constructs a declarative configuration of the FooCanaryPause type for use with
+// apply.
+func FooCanaryPause() *FooCanaryPauseApplyConfiguration {
+	return &FooCanaryPauseApplyConfiguration{}
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *FooCanaryPauseApplyConfiguration) WithDuration(value v1.Duration) *FooCanaryPauseApplyConfiguration {
+	b.Duration = &value
+	return b
+}
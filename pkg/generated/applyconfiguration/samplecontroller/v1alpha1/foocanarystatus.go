@@ -0,0 +1,98 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	samplecontrollerv1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// FooCanaryStatusApplyConfiguration represents a declarative configuration of the FooCanaryStatus type for use
+// with apply.
+type FooCanaryStatusApplyConfiguration struct {
+	DeploymentName    *string                                  `json:"deploymentName,omitempty"`
+	TemplateHash      *string                                  `json:"templateHash,omitempty"`
+	Phase             *samplecontrollerv1alpha1.FooCanaryPhase `json:"phase,omitempty"`
+	Step              *int32                                   `json:"step,omitempty"`
+	Replicas          *int32                                   `json:"replicas,omitempty"`
+	AvailableReplicas *int32                                   `json:"availableReplicas,omitempty"`
+	PauseStartTime    *v1.Time                                 `json:"pauseStartTime,omitempty"`
+}
+
+// FooCanaryStatusApplyConfiguration constructs a declarative configuration of the FooCanaryStatus type for use with
+// apply.
+func FooCanaryStatus() *FooCanaryStatusApplyConfiguration {
+	return &FooCanaryStatusApplyConfiguration{}
+}
+
+// WithDeploymentName sets the DeploymentName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeploymentName field is set to the value of the last call.
+func (b *FooCanaryStatusApplyConfiguration) WithDeploymentName(value string) *FooCanaryStatusApplyConfiguration {
+	b.DeploymentName = &value
+	return b
+}
+
+// WithTemplateHash sets the TemplateHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TemplateHash field is set to the value of the last call.
+func (b *FooCanaryStatusApplyConfiguration) WithTemplateHash(value string) *FooCanaryStatusApplyConfiguration {
+	b.TemplateHash = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *FooCanaryStatusApplyConfiguration) WithPhase(value samplecontrollerv1alpha1.FooCanaryPhase) *FooCanaryStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithStep sets the Step field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Step field is set to the value of the last call.
+func (b *FooCanaryStatusApplyConfiguration) WithStep(value int32) *FooCanaryStatusApplyConfiguration {
+	b.Step = &value
+	return b
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *FooCanaryStatusApplyConfiguration) WithReplicas(value int32) *FooCanaryStatusApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithAvailableReplicas sets the AvailableReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AvailableReplicas field is set to the value of the last call.
+func (b *FooCanaryStatusApplyConfiguration) WithAvailableReplicas(value int32) *FooCanaryStatusApplyConfiguration {
+	b.AvailableReplicas = &value
+	return b
+}
+
+// WithPauseStartTime sets the PauseStartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PauseStartTime field is set to the value of the last call.
+func (b *FooCanaryStatusApplyConfiguration) WithPauseStartTime(value v1.Time) *FooCanaryStatusApplyConfiguration {
+	b.PauseStartTime = &value
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// FooCanaryStepApplyConfiguration represents a declarative configuration of the FooCanaryStep type for use
+// with apply.
+type FooCanaryStepApplyConfiguration struct {
+	Replicas *intstr.IntOrString               `json:"replicas,omitempty"`
+	Pause    *FooCanaryPauseApplyConfiguration `json:"pause,omitempty"`
+}
+
+// FooCanaryStepApplyConfiguration constructs a declarative configuration of the FooCanaryStep type for use with
+// apply.
+func FooCanaryStep() *FooCanaryStepApplyConfiguration {
+	return &FooCanaryStepApplyConfiguration{}
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *FooCanaryStepApplyConfiguration) WithReplicas(value intstr.IntOrString) *FooCanaryStepApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithPause sets the Pause field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pause field is set to the value of the last call.
+func (b *FooCanaryStepApplyConfiguration) WithPause(value *FooCanaryPauseApplyConfiguration) *FooCanaryStepApplyConfiguration {
+	b.Pause = value
+	return b
+}
@@ -0,0 +1,166 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// FooStatusApplyConfiguration represents a declarative configuration of the FooStatus type for use
+// with apply.
+type FooStatusApplyConfiguration struct {
+	AvailableReplicas  *int32                                       `json:"availableReplicas,omitempty"`
+	DeploymentName     *string                                      `json:"deploymentName,omitempty"`
+	Replicas           *int32                                       `json:"replicas,omitempty"`
+	Selector           *string                                      `json:"selector,omitempty"`
+	ReadyReplicas      *int32                                       `json:"readyReplicas,omitempty"`
+	UpdatedReplicas    *int32                                       `json:"updatedReplicas,omitempty"`
+	ObservedGeneration *int64                                       `json:"observedGeneration,omitempty"`
+	LastSyncTime       *v1.Time                                     `json:"lastSyncTime,omitempty"`
+	Conditions         []metav1.ConditionApplyConfiguration         `json:"conditions,omitempty"`
+	Service            *FooServiceStatusApplyConfiguration          `json:"service,omitempty"`
+	DisruptionBudget   *FooDisruptionBudgetStatusApplyConfiguration `json:"disruptionBudget,omitempty"`
+	Autoscaling        *FooAutoscalingStatusApplyConfiguration      `json:"autoscaling,omitempty"`
+	Rollout            *FooRolloutStatusApplyConfiguration          `json:"rollout,omitempty"`
+	Canary             *FooCanaryStatusApplyConfiguration           `json:"canary,omitempty"`
+}
+
+// FooStatusApplyConfiguration constructs a declarative configuration of the FooStatus type for use with
+// apply.
+func FooStatus() *FooStatusApplyConfiguration {
+	return &FooStatusApplyConfiguration{}
+}
+
+// WithAvailableReplicas sets the AvailableReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AvailableReplicas field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithAvailableReplicas(value int32) *FooStatusApplyConfiguration {
+	b.AvailableReplicas = &value
+	return b
+}
+
+// WithDeploymentName sets the DeploymentName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeploymentName field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithDeploymentName(value string) *FooStatusApplyConfiguration {
+	b.DeploymentName = &value
+	return b
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithReplicas(value int32) *FooStatusApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithSelector(value string) *FooStatusApplyConfiguration {
+	b.Selector = &value
+	return b
+}
+
+// WithReadyReplicas sets the ReadyReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyReplicas field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithReadyReplicas(value int32) *FooStatusApplyConfiguration {
+	b.ReadyReplicas = &value
+	return b
+}
+
+// WithUpdatedReplicas sets the UpdatedReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdatedReplicas field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithUpdatedReplicas(value int32) *FooStatusApplyConfiguration {
+	b.UpdatedReplicas = &value
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithObservedGeneration(value int64) *FooStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithLastSyncTime sets the LastSyncTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSyncTime field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithLastSyncTime(value v1.Time) *FooStatusApplyConfiguration {
+	b.LastSyncTime = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *FooStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *FooStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithService sets the Service field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Service field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithService(value *FooServiceStatusApplyConfiguration) *FooStatusApplyConfiguration {
+	b.Service = value
+	return b
+}
+
+// WithDisruptionBudget sets the DisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisruptionBudget field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithDisruptionBudget(value *FooDisruptionBudgetStatusApplyConfiguration) *FooStatusApplyConfiguration {
+	b.DisruptionBudget = value
+	return b
+}
+
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithAutoscaling(value *FooAutoscalingStatusApplyConfiguration) *FooStatusApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithRollout sets the Rollout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollout field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithRollout(value *FooRolloutStatusApplyConfiguration) *FooStatusApplyConfiguration {
+	b.Rollout = value
+	return b
+}
+
+// WithCanary sets the Canary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Canary field is set to the value of the last call.
+func (b *FooStatusApplyConfiguration) WithCanary(value *FooCanaryStatusApplyConfiguration) *FooStatusApplyConfiguration {
+	b.Canary = value
+	return b
+}
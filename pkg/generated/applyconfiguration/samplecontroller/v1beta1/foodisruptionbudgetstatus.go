@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// FooDisruptionBudgetStatusApplyConfiguration represents a declarative configuration of the FooDisruptionBudgetStatus type for use
+// with apply.
+type FooDisruptionBudgetStatusApplyConfiguration struct {
+	Name               *string `json:"name,omitempty"`
+	DisruptionsAllowed *int32  `json:"disruptionsAllowed,omitempty"`
+	CurrentHealthy     *int32  `json:"currentHealthy,omitempty"`
+	DesiredHealthy     *int32  `json:"desiredHealthy,omitempty"`
+}
+
+// FooDisruptionBudgetStatusApplyConfiguration constructs a declarative configuration of the FooDisruptionBudgetStatus type for use with
+// apply.
+func FooDisruptionBudgetStatus() *FooDisruptionBudgetStatusApplyConfiguration {
+	return &FooDisruptionBudgetStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *FooDisruptionBudgetStatusApplyConfiguration) WithName(value string) *FooDisruptionBudgetStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithDisruptionsAllowed sets the DisruptionsAllowed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisruptionsAllowed field is set to the value of the last call.
+func (b *FooDisruptionBudgetStatusApplyConfiguration) WithDisruptionsAllowed(value int32) *FooDisruptionBudgetStatusApplyConfiguration {
+	b.DisruptionsAllowed = &value
+	return b
+}
+
+// WithCurrentHealthy sets the CurrentHealthy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentHealthy field is set to the value of the last call.
+func (b *FooDisruptionBudgetStatusApplyConfiguration) WithCurrentHealthy(value int32) *FooDisruptionBudgetStatusApplyConfiguration {
+	b.CurrentHealthy = &value
+	return b
+}
+
+// WithDesiredHealthy sets the DesiredHealthy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DesiredHealthy field is set to the value of the last call.
+func (b *FooDisruptionBudgetStatusApplyConfiguration) WithDesiredHealthy(value int32) *FooDisruptionBudgetStatusApplyConfiguration {
+	b.DesiredHealthy = &value
+	return b
+}
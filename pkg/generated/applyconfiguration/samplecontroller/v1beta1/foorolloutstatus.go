@@ -0,0 +1,79 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// FooRolloutStatusApplyConfiguration represents a declarative configuration of the FooRolloutStatus type for use
+// with apply.
+type FooRolloutStatusApplyConfiguration struct {
+	LastGoodRevision     *string                               `json:"lastGoodRevision,omitempty"`
+	LastGoodTemplateHash *string                               `json:"lastGoodTemplateHash,omitempty"`
+	LastGoodTemplate     *v1.PodTemplateSpecApplyConfiguration `json:"lastGoodTemplate,omitempty"`
+	FailedRevision       *string                               `json:"failedRevision,omitempty"`
+	FailedTemplateHash   *string                               `json:"failedTemplateHash,omitempty"`
+}
+
+// FooRolloutStatusApplyConfiguration constructs a declarative configuration of the FooRolloutStatus type for use with
+// apply.
+func FooRolloutStatus() *FooRolloutStatusApplyConfiguration {
+	return &FooRolloutStatusApplyConfiguration{}
+}
+
+// WithLastGoodRevision sets the LastGoodRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastGoodRevision field is set to the value of the last call.
+func (b *FooRolloutStatusApplyConfiguration) WithLastGoodRevision(value string) *FooRolloutStatusApplyConfiguration {
+	b.LastGoodRevision = &value
+	return b
+}
+
+// WithLastGoodTemplateHash sets the LastGoodTemplateHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastGoodTemplateHash field is set to the value of the last call.
+func (b *FooRolloutStatusApplyConfiguration) WithLastGoodTemplateHash(value string) *FooRolloutStatusApplyConfiguration {
+	b.LastGoodTemplateHash = &value
+	return b
+}
+
+// WithLastGoodTemplate sets the LastGoodTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastGoodTemplate field is set to the value of the last call.
+func (b *FooRolloutStatusApplyConfiguration) WithLastGoodTemplate(value *v1.PodTemplateSpecApplyConfiguration) *FooRolloutStatusApplyConfiguration {
+	b.LastGoodTemplate = value
+	return b
+}
+
+// WithFailedRevision sets the FailedRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedRevision field is set to the value of the last call.
+func (b *FooRolloutStatusApplyConfiguration) WithFailedRevision(value string) *FooRolloutStatusApplyConfiguration {
+	b.FailedRevision = &value
+	return b
+}
+
+// WithFailedTemplateHash sets the FailedTemplateHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedTemplateHash field is set to the value of the last call.
+func (b *FooRolloutStatusApplyConfiguration) WithFailedTemplateHash(value string) *FooRolloutStatusApplyConfiguration {
+	b.FailedTemplateHash = &value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// FooServiceStatusApplyConfiguration represents a declarative configuration of the FooServiceStatus type for use
+// with apply.
+type FooServiceStatusApplyConfiguration struct {
+	Name      *string                            `json:"name,omitempty"`
+	ClusterIP *string                            `json:"clusterIP,omitempty"`
+	Ports     []v1.ServicePortApplyConfiguration `json:"ports,omitempty"`
+}
+
+// FooServiceStatusApplyConfiguration constructs a declarative configuration of the FooServiceStatus type for use with
+// apply.
+func FooServiceStatus() *FooServiceStatusApplyConfiguration {
+	return &FooServiceStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *FooServiceStatusApplyConfiguration) WithName(value string) *FooServiceStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithClusterIP sets the ClusterIP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterIP field is set to the value of the last call.
+func (b *FooServiceStatusApplyConfiguration) WithClusterIP(value string) *FooServiceStatusApplyConfiguration {
+	b.ClusterIP = &value
+	return b
+}
+
+// WithPorts adds the given value to the Ports field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Ports field.
+func (b *FooServiceStatusApplyConfiguration) WithPorts(values ...*v1.ServicePortApplyConfiguration) *FooServiceStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPorts")
+		}
+		b.Ports = append(b.Ports, *values[i])
+	}
+	return b
+}
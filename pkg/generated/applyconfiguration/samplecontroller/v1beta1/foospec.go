@@ -0,0 +1,116 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	samplecontrollerv1beta1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1beta1"
+)
+
+// FooSpecApplyConfiguration represents a declarative configuration of the FooSpec type for use
+// with apply.
+type FooSpecApplyConfiguration struct {
+	Deployment       *FooDeploymentApplyConfiguration        `json:"deployment,omitempty"`
+	Template         *v1.PodTemplateSpecApplyConfiguration   `json:"template,omitempty"`
+	DeletionPolicy   *samplecontrollerv1beta1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+	Service          *FooServiceApplyConfiguration           `json:"service,omitempty"`
+	Suspend          *bool                                   `json:"suspend,omitempty"`
+	DisruptionBudget *FooDisruptionBudgetApplyConfiguration  `json:"disruptionBudget,omitempty"`
+	Autoscaling      *FooAutoscalingApplyConfiguration       `json:"autoscaling,omitempty"`
+	Rollback         *FooRollbackApplyConfiguration          `json:"rollback,omitempty"`
+	Strategy         *FooStrategyApplyConfiguration          `json:"strategy,omitempty"`
+}
+
+// FooSpecApplyConfiguration constructs a declarative configuration of the FooSpec type for use with
+// apply.
+func FooSpec() *FooSpecApplyConfiguration {
+	return &FooSpecApplyConfiguration{}
+}
+
+// WithDeployment sets the Deployment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Deployment field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithDeployment(value *FooDeploymentApplyConfiguration) *FooSpecApplyConfiguration {
+	b.Deployment = value
+	return b
+}
+
+// WithTemplate sets the Template field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Template field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithTemplate(value *v1.PodTemplateSpecApplyConfiguration) *FooSpecApplyConfiguration {
+	b.Template = value
+	return b
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithDeletionPolicy(value samplecontrollerv1beta1.DeletionPolicy) *FooSpecApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
+
+// WithService sets the Service field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Service field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithService(value *FooServiceApplyConfiguration) *FooSpecApplyConfiguration {
+	b.Service = value
+	return b
+}
+
+// WithSuspend sets the Suspend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Suspend field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithSuspend(value bool) *FooSpecApplyConfiguration {
+	b.Suspend = &value
+	return b
+}
+
+// WithDisruptionBudget sets the DisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisruptionBudget field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithDisruptionBudget(value *FooDisruptionBudgetApplyConfiguration) *FooSpecApplyConfiguration {
+	b.DisruptionBudget = value
+	return b
+}
+
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithAutoscaling(value *FooAutoscalingApplyConfiguration) *FooSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithRollback sets the Rollback field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollback field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithRollback(value *FooRollbackApplyConfiguration) *FooSpecApplyConfiguration {
+	b.Rollback = value
+	return b
+}
+
+// WithStrategy sets the Strategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Strategy field is set to the value of the last call.
+func (b *FooSpecApplyConfiguration) WithStrategy(value *FooStrategyApplyConfiguration) *FooSpecApplyConfiguration {
+	b.Strategy = value
+	return b
+}
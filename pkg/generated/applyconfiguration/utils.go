@@ -0,0 +1,112 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	v1beta1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1beta1"
+	internal "k8s.io/sample-controller/pkg/generated/applyconfiguration/internal"
+	samplecontrollerv1alpha1 "k8s.io/sample-controller/pkg/generated/applyconfiguration/samplecontroller/v1alpha1"
+	samplecontrollerv1beta1 "k8s.io/sample-controller/pkg/generated/applyconfiguration/samplecontroller/v1beta1"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=samplecontroller.k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("Foo"):
+		return &samplecontrollerv1alpha1.FooApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooAutoscaling"):
+		return &samplecontrollerv1alpha1.FooAutoscalingApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooAutoscalingStatus"):
+		return &samplecontrollerv1alpha1.FooAutoscalingStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooCanaryPause"):
+		return &samplecontrollerv1alpha1.FooCanaryPauseApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooCanaryStatus"):
+		return &samplecontrollerv1alpha1.FooCanaryStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooCanaryStep"):
+		return &samplecontrollerv1alpha1.FooCanaryStepApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooCanaryStrategy"):
+		return &samplecontrollerv1alpha1.FooCanaryStrategyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooDisruptionBudget"):
+		return &samplecontrollerv1alpha1.FooDisruptionBudgetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooDisruptionBudgetStatus"):
+		return &samplecontrollerv1alpha1.FooDisruptionBudgetStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooRollback"):
+		return &samplecontrollerv1alpha1.FooRollbackApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooRolloutStatus"):
+		return &samplecontrollerv1alpha1.FooRolloutStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooService"):
+		return &samplecontrollerv1alpha1.FooServiceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooServiceStatus"):
+		return &samplecontrollerv1alpha1.FooServiceStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooSpec"):
+		return &samplecontrollerv1alpha1.FooSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooStatus"):
+		return &samplecontrollerv1alpha1.FooStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FooStrategy"):
+		return &samplecontrollerv1alpha1.FooStrategyApplyConfiguration{}
+
+		// Group=samplecontroller.k8s.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("Foo"):
+		return &samplecontrollerv1beta1.FooApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooAutoscaling"):
+		return &samplecontrollerv1beta1.FooAutoscalingApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooAutoscalingStatus"):
+		return &samplecontrollerv1beta1.FooAutoscalingStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooCanaryPause"):
+		return &samplecontrollerv1beta1.FooCanaryPauseApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooCanaryStatus"):
+		return &samplecontrollerv1beta1.FooCanaryStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooCanaryStep"):
+		return &samplecontrollerv1beta1.FooCanaryStepApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooCanaryStrategy"):
+		return &samplecontrollerv1beta1.FooCanaryStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooDeployment"):
+		return &samplecontrollerv1beta1.FooDeploymentApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooDisruptionBudget"):
+		return &samplecontrollerv1beta1.FooDisruptionBudgetApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooDisruptionBudgetStatus"):
+		return &samplecontrollerv1beta1.FooDisruptionBudgetStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooRollback"):
+		return &samplecontrollerv1beta1.FooRollbackApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooRolloutStatus"):
+		return &samplecontrollerv1beta1.FooRolloutStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooService"):
+		return &samplecontrollerv1beta1.FooServiceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooServiceStatus"):
+		return &samplecontrollerv1beta1.FooServiceStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooSpec"):
+		return &samplecontrollerv1beta1.FooSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooStatus"):
+		return &samplecontrollerv1beta1.FooStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FooStrategy"):
+		return &samplecontrollerv1beta1.FooStrategyApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) *testing.TypeConverter {
+	return &testing.TypeConverter{Scheme: scheme, TypeResolver: internal.Parser()}
+}
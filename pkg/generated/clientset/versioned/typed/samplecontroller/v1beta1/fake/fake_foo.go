@@ -0,0 +1,78 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	context "context"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gentype "k8s.io/client-go/gentype"
+	testing "k8s.io/client-go/testing"
+	v1beta1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1beta1"
+	samplecontrollerv1beta1 "k8s.io/sample-controller/pkg/generated/applyconfiguration/samplecontroller/v1beta1"
+	typedsamplecontrollerv1beta1 "k8s.io/sample-controller/pkg/generated/clientset/versioned/typed/samplecontroller/v1beta1"
+)
+
+// fakeFoos implements FooInterface
+type fakeFoos struct {
+	*gentype.FakeClientWithListAndApply[*v1beta1.Foo, *v1beta1.FooList, *samplecontrollerv1beta1.FooApplyConfiguration]
+	Fake *FakeSamplecontrollerV1beta1
+}
+
+func newFakeFoos(fake *FakeSamplecontrollerV1beta1, namespace string) typedsamplecontrollerv1beta1.FooInterface {
+	return &fakeFoos{
+		gentype.NewFakeClientWithListAndApply[*v1beta1.Foo, *v1beta1.FooList, *samplecontrollerv1beta1.FooApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1beta1.SchemeGroupVersion.WithResource("foos"),
+			v1beta1.SchemeGroupVersion.WithKind("Foo"),
+			func() *v1beta1.Foo { return &v1beta1.Foo{} },
+			func() *v1beta1.FooList { return &v1beta1.FooList{} },
+			func(dst, src *v1beta1.FooList) { dst.ListMeta = src.ListMeta },
+			func(list *v1beta1.FooList) []*v1beta1.Foo { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1beta1.FooList, items []*v1beta1.Foo) { list.Items = gentype.FromPointerSlice(items) },
+		),
+		fake,
+	}
+}
+
+// GetScale takes name of the foo, and returns the corresponding scale object, and an error if there is any.
+func (c *fakeFoos) GetScale(ctx context.Context, fooName string, options v1.GetOptions) (result *autoscalingv1.Scale, err error) {
+	emptyResult := &autoscalingv1.Scale{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceActionWithOptions(c.Resource(), c.Namespace(), "scale", fooName, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
+
+// UpdateScale takes the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.
+func (c *fakeFoos) UpdateScale(ctx context.Context, fooName string, scale *autoscalingv1.Scale, opts v1.UpdateOptions) (result *autoscalingv1.Scale, err error) {
+	emptyResult := &autoscalingv1.Scale{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(c.Resource(), "scale", c.Namespace(), scale, opts), &autoscalingv1.Scale{})
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
@@ -0,0 +1,107 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	context "context"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+	samplecontrollerv1beta1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1beta1"
+	applyconfigurationsamplecontrollerv1beta1 "k8s.io/sample-controller/pkg/generated/applyconfiguration/samplecontroller/v1beta1"
+	scheme "k8s.io/sample-controller/pkg/generated/clientset/versioned/scheme"
+)
+
+// FoosGetter has a method to return a FooInterface.
+// A group's client should implement this interface.
+type FoosGetter interface {
+	Foos(namespace string) FooInterface
+}
+
+// FooInterface has methods to work with Foo resources.
+type FooInterface interface {
+	Create(ctx context.Context, foo *samplecontrollerv1beta1.Foo, opts v1.CreateOptions) (*samplecontrollerv1beta1.Foo, error)
+	Update(ctx context.Context, foo *samplecontrollerv1beta1.Foo, opts v1.UpdateOptions) (*samplecontrollerv1beta1.Foo, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, foo *samplecontrollerv1beta1.Foo, opts v1.UpdateOptions) (*samplecontrollerv1beta1.Foo, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*samplecontrollerv1beta1.Foo, error)
+	List(ctx context.Context, opts v1.ListOptions) (*samplecontrollerv1beta1.FooList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *samplecontrollerv1beta1.Foo, err error)
+	Apply(ctx context.Context, foo *applyconfigurationsamplecontrollerv1beta1.FooApplyConfiguration, opts v1.ApplyOptions) (result *samplecontrollerv1beta1.Foo, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, foo *applyconfigurationsamplecontrollerv1beta1.FooApplyConfiguration, opts v1.ApplyOptions) (result *samplecontrollerv1beta1.Foo, err error)
+	GetScale(ctx context.Context, fooName string, options v1.GetOptions) (*autoscalingv1.Scale, error)
+	UpdateScale(ctx context.Context, fooName string, scale *autoscalingv1.Scale, opts v1.UpdateOptions) (*autoscalingv1.Scale, error)
+
+	FooExpansion
+}
+
+// foos implements FooInterface
+type foos struct {
+	*gentype.ClientWithListAndApply[*samplecontrollerv1beta1.Foo, *samplecontrollerv1beta1.FooList, *applyconfigurationsamplecontrollerv1beta1.FooApplyConfiguration]
+}
+
+// newFoos returns a Foos
+func newFoos(c *SamplecontrollerV1beta1Client, namespace string) *foos {
+	return &foos{
+		gentype.NewClientWithListAndApply[*samplecontrollerv1beta1.Foo, *samplecontrollerv1beta1.FooList, *applyconfigurationsamplecontrollerv1beta1.FooApplyConfiguration](
+			"foos",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *samplecontrollerv1beta1.Foo { return &samplecontrollerv1beta1.Foo{} },
+			func() *samplecontrollerv1beta1.FooList { return &samplecontrollerv1beta1.FooList{} },
+		),
+	}
+}
+
+// GetScale takes name of the foo, and returns the corresponding autoscalingv1.Scale object, and an error if there is any.
+func (c *foos) GetScale(ctx context.Context, fooName string, options v1.GetOptions) (result *autoscalingv1.Scale, err error) {
+	result = &autoscalingv1.Scale{}
+	err = c.GetClient().Get().
+		Namespace(c.GetNamespace()).
+		Resource("foos").
+		Name(fooName).
+		SubResource("scale").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateScale takes the top resource name and the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.
+func (c *foos) UpdateScale(ctx context.Context, fooName string, scale *autoscalingv1.Scale, opts v1.UpdateOptions) (result *autoscalingv1.Scale, err error) {
+	result = &autoscalingv1.Scale{}
+	err = c.GetClient().Put().
+		Namespace(c.GetNamespace()).
+		Resource("foos").
+		Name(fooName).
+		SubResource("scale").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(scale).
+		Do(ctx).
+		Into(result)
+	return
+}
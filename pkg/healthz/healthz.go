@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthz serves the liveness and readiness endpoints of the sample
+// controller, built from named checks that subsystems register.
+package healthz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Checker is a named health check. It has the same shape as the checks of
+// the Kubernetes components, so adaptors like the one of client-go's leader
+// election can be registered directly.
+type Checker interface {
+	// Name is used in the output and as the path of the individual check.
+	Name() string
+	// Check returns nil if the check passes.
+	Check(req *http.Request) error
+}
+
+type namedCheck struct {
+	name  string
+	check func(req *http.Request) error
+}
+
+func (c *namedCheck) Name() string                  { return c.name }
+func (c *namedCheck) Check(req *http.Request) error { return c.check(req) }
+
+// NamedCheck returns a Checker that runs check under the given name.
+func NamedCheck(name string, check func(req *http.Request) error) Checker {
+	return &namedCheck{name: name, check: check}
+}
+
+// PingCheck passes whenever the server is able to answer.
+var PingCheck = NamedCheck("ping", func(*http.Request) error { return nil })
+
+// Server serves /healthz and /readyz, each running the checks registered for
+// it. Individual checks are served below the endpoint, e.g. /readyz/ping.
+type Server struct {
+	addr string
+
+	lock    sync.RWMutex
+	healthz []Checker
+	readyz  []Checker
+}
+
+// NewServer returns a Server that will listen on addr.
+func NewServer(addr string) *Server {
+	return &Server{addr: addr}
+}
+
+// AddHealthzChecks registers checks run by the liveness endpoint /healthz.
+func (s *Server) AddHealthzChecks(checks ...Checker) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.healthz = append(s.healthz, checks...)
+}
+
+// AddReadyzChecks registers checks run by the readiness endpoint /readyz.
+func (s *Server) AddReadyzChecks(checks ...Checker) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.readyz = append(s.readyz, checks...)
+}
+
+// Handler returns the http.Handler serving both endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", s.endpoint("healthz", &s.healthz))
+	mux.Handle("/healthz/", s.endpoint("healthz", &s.healthz))
+	mux.Handle("/readyz", s.endpoint("readyz", &s.readyz))
+	mux.Handle("/readyz/", s.endpoint("readyz", &s.readyz))
+	return mux
+}
+
+// endpoint serves the checks in *checks under /name. The slice is read on
+// every request, so checks added later are picked up.
+func (s *Server) endpoint(name string, checks *[]Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.RLock()
+		registered := append([]Checker(nil), *checks...)
+		s.lock.RUnlock()
+
+		if only := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+name), "/"); only != "" {
+			for _, check := range registered {
+				if check.Name() != only {
+					continue
+				}
+				if err := check.Check(r); err != nil {
+					http.Error(w, fmt.Sprintf("internal server error: %v", err), http.StatusInternalServerError)
+					return
+				}
+				fmt.Fprint(w, "ok")
+				return
+			}
+			http.NotFound(w, r)
+			return
+		}
+
+		var out bytes.Buffer
+		var failed []string
+		for _, check := range registered {
+			if err := check.Check(r); err != nil {
+				klog.FromContext(r.Context()).V(2).Info("Health check failed", "endpoint", name, "check", check.Name(), "err", err)
+				fmt.Fprintf(&out, "[-]%s failed: %v\n", check.Name(), err)
+				failed = append(failed, check.Name())
+				continue
+			}
+			fmt.Fprintf(&out, "[+]%s ok\n", check.Name())
+		}
+		if len(failed) > 0 {
+			fmt.Fprintf(&out, "%s check failed\n", name)
+			http.Error(w, out.String(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if _, verbose := r.URL.Query()["verbose"]; verbose {
+			fmt.Fprintf(&out, "%s check passed\n", name)
+			_, _ = out.WriteTo(w)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	})
+}
+
+// Run serves requests until ctx is cancelled, at which point the server is
+// shut down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "Error shutting down health probe server")
+		}
+	}()
+
+	logger.Info("Starting health probe server", "address", s.addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
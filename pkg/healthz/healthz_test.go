@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	ready := false
+	s := NewServer(":0")
+	s.AddHealthzChecks(PingCheck)
+	s.AddReadyzChecks(PingCheck, NamedCheck("synced", func(*http.Request) error {
+		if !ready {
+			return errors.New("caches not synced")
+		}
+		return nil
+	}))
+	handler := s.Handler()
+
+	testCases := []struct {
+		name         string
+		path         string
+		ready        bool
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "healthz", path: "/healthz", expectedCode: http.StatusOK, expectedBody: "ok"},
+		{name: "not ready", path: "/readyz", expectedCode: http.StatusInternalServerError, expectedBody: "[+]ping ok\n[-]synced failed: caches not synced\nreadyz check failed"},
+		{name: "ready", path: "/readyz", ready: true, expectedCode: http.StatusOK, expectedBody: "ok"},
+		{name: "verbose", path: "/readyz?verbose", ready: true, expectedCode: http.StatusOK, expectedBody: "[+]ping ok\n[+]synced ok\nreadyz check passed"},
+		{name: "single check", path: "/readyz/synced", expectedCode: http.StatusInternalServerError, expectedBody: "caches not synced"},
+		{name: "passing single check", path: "/readyz/ping", expectedCode: http.StatusOK, expectedBody: "ok"},
+		{name: "unknown check", path: "/healthz/synced", expectedCode: http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ready = tc.ready
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tc.expectedBody) {
+				t.Errorf("expected body to contain %q, got %q", tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestChecksAddedLater(t *testing.T) {
+	s := NewServer(":0")
+	handler := s.Handler()
+	s.AddHealthzChecks(NamedCheck("broken", func(*http.Request) error { return errors.New("broken") }))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
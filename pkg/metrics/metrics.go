@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes Prometheus metrics about the sample controller,
+// its workqueue and its API clients.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	clientmetrics "k8s.io/client-go/tools/metrics"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const namespace = "sample_controller"
+
+// Results a reconciliation is labelled with.
+const (
+	// ResultSuccess is a reconciliation that converged the Foo.
+	ResultSuccess = "success"
+	// ResultConflict is a reconciliation that lost a race with another
+	// writer and is retried.
+	ResultConflict = "conflict"
+	// ResultRequeue is a reconciliation that hit a transient condition of
+	// the API server and is retried.
+	ResultRequeue = "requeue"
+	// ResultError is any other failed reconciliation.
+	ResultError = "error"
+)
+
+// Results a configuration reload is labelled with.
+const (
+	// ReloadApplied is a reload whose configuration is now in effect.
+	ReloadApplied = "applied"
+	// ReloadRejected is a reload whose configuration was invalid or changed
+	// settings that need a restart; the previous configuration stays in
+	// effect.
+	ReloadRejected = "rejected"
+)
+
+var (
+	// Registry holds all metrics of the sample controller.
+	Registry = prometheus.NewRegistry()
+
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_total",
+		Help:      "Number of Foo reconciliations, partitioned by result.",
+	}, []string{"result"})
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Time taken to reconcile a Foo, partitioned by result.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"result"})
+	configReloadTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "config_reload_total",
+		Help:      "Number of configuration reloads, partitioned by result.",
+	}, []string{"result"})
+	configLastReloadSuccessful = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "config_last_reload_successful",
+		Help:      "Whether the last configuration reload was applied (1) or rejected (0).",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		reconcileTotal,
+		reconcileDuration,
+		configReloadTotal,
+		configLastReloadSuccessful,
+	)
+	Registry.MustRegister(workqueueCollectors...)
+	Registry.MustRegister(requestLatency, requestResult)
+}
+
+// Register hooks the workqueue and client-go metrics up to Registry. Both
+// only take the first provider set, and workqueues only report metrics if
+// the provider was set before they were created, so Register has to be called
+// before any client or controller is built.
+func Register() {
+	workqueue.SetProvider(workqueueMetricsProvider{})
+	clientmetrics.Register(clientmetrics.RegisterOpts{
+		RequestLatency: latencyAdapter{requestLatency},
+		RequestResult:  resultAdapter{requestResult},
+	})
+}
+
+// ObserveReconcile records a reconciliation with the given result that took
+// duration.
+func ObserveReconcile(result string, duration time.Duration) {
+	reconcileTotal.WithLabelValues(result).Inc()
+	reconcileDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// ObserveConfigReload records a configuration reload with the given result.
+func ObserveConfigReload(result string) {
+	configReloadTotal.WithLabelValues(result).Inc()
+	if result == ReloadApplied {
+		configLastReloadSuccessful.Set(1)
+	} else {
+		configLastReloadSuccessful.Set(0)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestHandler(t *testing.T) {
+	Register()
+
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[string](),
+		workqueue.TypedRateLimitingQueueConfig[string]{Name: "test"})
+	defer queue.ShutDown()
+	queue.Add("item")
+
+	ObserveReconcile(ResultSuccess, 10*time.Millisecond)
+	ObserveReconcile(ResultConflict, 20*time.Millisecond)
+	ObserveConfigReload(ReloadRejected)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	body := rec.Body.String()
+	for _, expected := range []string{
+		`sample_controller_reconcile_total{result="success"} 1`,
+		`sample_controller_reconcile_total{result="conflict"} 1`,
+		`sample_controller_reconcile_duration_seconds_count{result="success"} 1`,
+		`sample_controller_config_reload_total{result="rejected"} 1`,
+		`sample_controller_config_last_reload_successful 0`,
+		`workqueue_adds_total{name="test"} 1`,
+		`workqueue_depth{name="test"} 1`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected %q in metrics output", expected)
+		}
+	}
+}
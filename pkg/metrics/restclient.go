@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// requestLatency is partitioned by host rather than by URL, as the URL
+	// path contains object names.
+	requestLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: "rest_client",
+		Name:      "request_duration_seconds",
+		Help:      "Request latency in seconds, partitioned by verb and host.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"verb", "host"})
+	requestResult = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "rest_client",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests, partitioned by status code, method and host.",
+	}, []string{"code", "method", "host"})
+)
+
+// latencyAdapter implements metrics.LatencyMetric of client-go.
+type latencyAdapter struct {
+	metric *prometheus.HistogramVec
+}
+
+func (l latencyAdapter) Observe(_ context.Context, verb string, u url.URL, latency time.Duration) {
+	l.metric.WithLabelValues(verb, u.Host).Observe(latency.Seconds())
+}
+
+// resultAdapter implements metrics.ResultMetric of client-go.
+type resultAdapter struct {
+	metric *prometheus.CounterVec
+}
+
+func (r resultAdapter) Increment(_ context.Context, code, method, host string) {
+	r.metric.WithLabelValues(code, method, host).Inc()
+}
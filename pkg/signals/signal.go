@@ -42,3 +42,21 @@ func SetupSignalHandler() context.Context {
 
 	return ctx
 }
+
+// NotifyReload returns a channel receiving a value whenever the process is
+// asked to reload its configuration with SIGHUP, until ctx is cancelled.
+// Signals arriving while a value is still pending are merged into it. Every
+// call subscribes a channel of its own. On platforms without SIGHUP, nothing
+// is ever received.
+func NotifyReload(ctx context.Context) <-chan os.Signal {
+	c := make(chan os.Signal, 1)
+	if len(reloadSignals) == 0 {
+		return c
+	}
+	signal.Notify(c, reloadSignals...)
+	go func() {
+		<-ctx.Done()
+		signal.Stop(c)
+	}()
+	return c
+}
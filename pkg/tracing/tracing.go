@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing exports OpenTelemetry traces of the sample controller's
+// reconciliations and of the requests its API clients send.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+// ServiceName is the service.name of the spans of the controller.
+const ServiceName = "sample-controller"
+
+const instrumentationName = "k8s.io/sample-controller"
+
+// Setup installs the global TracerProvider, exporting a share of the traces
+// given by samplingRatio over OTLP/HTTP to endpoint, e.g.
+// http://localhost:4318, and the W3C trace context propagator. The returned
+// function flushes the spans not exported yet and stops exporting.
+func Setup(ctx context.Context, endpoint string, samplingRatio float64) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the controller, taken from the global
+// TracerProvider. Spans are dropped until Setup is called.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// WrapTransport is a transport.WrapperFunc, for rest.Config.Wrap, that
+// records a client span for every request made within a span, e.g. by a
+// reconciliation, and carries the trace context in its headers. The list and
+// watch requests of the informers, made outside of any span, are not traced.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(rt,
+		otelhttp.WithFilter(func(req *http.Request) bool {
+			return trace.SpanContextFromContext(req.Context()).IsValid()
+		}),
+		otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+			return req.Method + " " + req.URL.Path
+		}),
+	)
+}
+
+// LoggerWithSpan returns logger with the IDs of the trace and span in ctx,
+// so that its log lines can be matched with the trace. The logger is
+// returned as is if ctx holds no span that is recorded.
+func LoggerWithSpan(ctx context.Context, logger klog.Logger) klog.Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return logger
+	}
+	return klog.LoggerWithValues(logger, "traceID", spanContext.TraceID().String(), "spanID", spanContext.SpanID().String())
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2/ktesting"
+)
+
+// collector is an OTLP/HTTP trace collector, keeping the spans exported to
+// it by name.
+type collector struct {
+	lock  sync.Mutex
+	spans map[string]string // span name to trace ID
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || r.URL.Path != "/v1/traces" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	var req collectortrace.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, resourceSpans := range req.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				c.spans[span.Name] = hex.EncodeToString(span.TraceId)
+			}
+		}
+	}
+	out, _ := proto.Marshal(&collectortrace.ExportTraceServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(out)
+}
+
+func TestSetupExportsSpans(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	c := &collector{spans: map[string]string{}}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	// The API server receives the trace context of the requests.
+	var traceparent string
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+	}))
+	defer apiserver.Close()
+
+	shutdown, err := Setup(ctx, server.URL, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{Transport: WrapTransport(http.DefaultTransport)}
+	// Requests made outside of a span are not traced.
+	resp, err := client.Get(apiserver.URL + "/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if traceparent != "" {
+		t.Errorf("expected no trace context outside of a span, got %q", traceparent)
+	}
+
+	spanCtx, span := Tracer().Start(ctx, "reconcile")
+	traceID := span.SpanContext().TraceID().String()
+	req, err := http.NewRequestWithContext(spanCtx, http.MethodGet, apiserver.URL+"/apis", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	span.End()
+	if !strings.Contains(traceparent, traceID) {
+		t.Errorf("expected the trace context of trace %s, got %q", traceID, traceparent)
+	}
+
+	if err := shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, name := range []string{"reconcile", "GET /apis"} {
+		if c.spans[name] != traceID {
+			t.Errorf("expected span %q of trace %s to be exported, got %v", name, traceID, c.spans)
+		}
+	}
+	if _, ok := c.spans["GET /api"]; ok {
+		t.Errorf("expected no span for the request outside of a span, got %v", c.spans)
+	}
+}
+
+func TestLoggerWithSpan(t *testing.T) {
+	logger := ktesting.NewLogger(t, ktesting.NewConfig(ktesting.BufferLogs(true)))
+	ctx := context.Background()
+	LoggerWithSpan(ctx, logger).Info("outside of a span")
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	ctx = trace.ContextWithSpanContext(ctx, spanContext)
+	LoggerWithSpan(ctx, logger).Info("within a span")
+
+	lines := strings.Split(strings.TrimSpace(logger.GetSink().(ktesting.Underlier).GetBuffer().String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", lines)
+	}
+	if strings.Contains(lines[0], "traceID") {
+		t.Errorf("expected no trace ID outside of a span, got %q", lines[0])
+	}
+	for _, expected := range []string{`traceID="` + spanContext.TraceID().String(), `spanID="` + spanContext.SpanID().String()} {
+		if !strings.Contains(lines[1], expected) {
+			t.Errorf("expected %s in %q", expected, lines[1])
+		}
+	}
+}
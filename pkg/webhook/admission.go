@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// admitFunc decides on a single admission request. The UID of the response
+// is filled in by admissionHandler.
+type admitFunc func(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse
+
+// admissionHandler serves the admission.k8s.io/v1 AdmissionReviews sent to
+// an admission webhook.
+type admissionHandler struct {
+	admit admitFunc
+}
+
+func (h *admissionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	response := h.admit(r.Context(), review.Request)
+	response.UID = review.Request.UID
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&admissionv1.AdmissionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	}); err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode AdmissionReview: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// allowed returns a response admitting the request.
+func allowed() *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// denied returns a response rejecting the request with status.
+func denied(status metav1.Status) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: false, Result: &status}
+}
+
+// badRequest returns a response rejecting a request that could not be
+// decoded.
+func badRequest(err error) *admissionv1.AdmissionResponse {
+	return denied(metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusBadRequest,
+		Reason:  metav1.StatusReasonBadRequest,
+		Message: err.Error(),
+	})
+}
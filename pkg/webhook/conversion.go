@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	kjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ConversionReview describes a conversion request/response. It mirrors the
+// apiextensions.k8s.io/v1 type of the same name, which is declared here so
+// the controller does not depend on k8s.io/apiextensions-apiserver for three
+// small wire types.
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	// Request describes the attributes for the conversion request.
+	Request *ConversionRequest `json:"request,omitempty"`
+	// Response describes the attributes for the conversion response.
+	Response *ConversionResponse `json:"response,omitempty"`
+}
+
+// ConversionRequest describes the conversion request parameters.
+type ConversionRequest struct {
+	// UID is an identifier for the individual request/response.
+	UID types.UID `json:"uid"`
+	// DesiredAPIVersion is the version to convert given objects to.
+	DesiredAPIVersion string `json:"desiredAPIVersion"`
+	// Objects is the list of custom resource objects to be converted.
+	Objects []runtime.RawExtension `json:"objects"`
+}
+
+// ConversionResponse describes a conversion response.
+type ConversionResponse struct {
+	// UID is copied from the corresponding request.
+	UID types.UID `json:"uid"`
+	// ConvertedObjects is the list of converted objects, in the same order
+	// as the request.
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	// Result contains the result of conversion with extra details if the
+	// conversion failed.
+	Result metav1.Status `json:"result"`
+}
+
+type conversionHandler struct {
+	scheme     *runtime.Scheme
+	decoder    runtime.Decoder
+	serializer runtime.Serializer
+}
+
+// NewConversionHandler returns a handler serving ConversionReviews for the
+// types registered in scheme. Objects are converted through the internal
+// version of their group, so scheme must contain the internal types and the
+// conversions of every served version.
+func NewConversionHandler(scheme *runtime.Scheme) http.Handler {
+	return &conversionHandler{
+		scheme:     scheme,
+		decoder:    serializer.NewCodecFactory(scheme).UniversalDeserializer(),
+		serializer: kjson.NewSerializerWithOptions(kjson.DefaultMetaFactory, scheme, scheme, kjson.SerializerOptions{}),
+	}
+}
+
+func (h *conversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "ConversionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.convert(review.Request)
+	if err != nil {
+		response = &ConversionResponse{
+			UID: review.Request.UID,
+			Result: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	}); err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode ConversionReview: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func (h *conversionHandler) convert(request *ConversionRequest) (*ConversionResponse, error) {
+	desired, err := schema.ParseGroupVersion(request.DesiredAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	internal := schema.GroupVersion{Group: desired.Group, Version: runtime.APIVersionInternal}
+
+	response := &ConversionResponse{
+		UID:              request.UID,
+		ConvertedObjects: make([]runtime.RawExtension, 0, len(request.Objects)),
+		Result:           metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, raw := range request.Objects {
+		obj, gvk, err := h.decoder.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode object: %w", err)
+		}
+		hub, err := h.scheme.ConvertToVersion(obj, internal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s to internal version: %w", gvk, err)
+		}
+		out, err := h.scheme.ConvertToVersion(hub, desired)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s to %s: %w", gvk.Kind, desired, err)
+		}
+		buf := &bytes.Buffer{}
+		if err := h.serializer.Encode(out, buf); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", gvk.Kind, err)
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: buf.Bytes()})
+	}
+	return response, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/install"
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1beta1"
+)
+
+func newTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	return scheme
+}
+
+// review sends a single object through the conversion handler and returns
+// the converted object.
+func review(t *testing.T, handler http.Handler, desiredAPIVersion string, obj runtime.Object) []byte {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(&ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request: &ConversionRequest{
+			UID:               "test-uid",
+			DesiredAPIVersion: desiredAPIVersion,
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d: %s", w.Code, w.Body.String())
+	}
+
+	resp := &ConversionReview{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Response == nil || resp.Response.UID != "test-uid" {
+		t.Fatalf("unexpected response: %+v", resp.Response)
+	}
+	if resp.Response.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("conversion failed: %s", resp.Response.Result.Message)
+	}
+	if len(resp.Response.ConvertedObjects) != 1 {
+		t.Fatalf("expected 1 converted object, got %d", len(resp.Response.ConvertedObjects))
+	}
+	return resp.Response.ConvertedObjects[0].Raw
+}
+
+func TestConvertV1alpha1ToV1beta1AndBack(t *testing.T) {
+	handler := NewConversionHandler(newTestScheme())
+	replicas := int32(3)
+	foo := &v1alpha1.Foo{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Foo"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"team": "a"},
+		},
+		Spec: v1alpha1.FooSpec{
+			DeploymentName: "test-deployment",
+			Replicas:       &replicas,
+		},
+		Status: v1alpha1.FooStatus{
+			AvailableReplicas: 2,
+		},
+	}
+
+	beta := &v1beta1.Foo{}
+	if err := json.Unmarshal(review(t, handler, v1beta1.SchemeGroupVersion.String(), foo), beta); err != nil {
+		t.Fatal(err)
+	}
+	if beta.APIVersion != v1beta1.SchemeGroupVersion.String() || beta.Kind != "Foo" {
+		t.Errorf("unexpected type meta: %v", beta.TypeMeta)
+	}
+	if beta.Spec.Deployment.Name != "test-deployment" || beta.Spec.Deployment.Replicas == nil || *beta.Spec.Deployment.Replicas != 3 {
+		t.Errorf("unexpected spec.deployment: %+v", beta.Spec.Deployment)
+	}
+	if beta.Status.AvailableReplicas != 2 {
+		t.Errorf("unexpected status: %+v", beta.Status)
+	}
+
+	alpha := &v1alpha1.Foo{}
+	if err := json.Unmarshal(review(t, handler, v1alpha1.SchemeGroupVersion.String(), beta), alpha); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(foo, alpha) {
+		t.Errorf("round trip changed the object:\nexpected %+v\ngot      %+v", foo, alpha)
+	}
+}
+
+func TestConvertUnknownVersion(t *testing.T) {
+	handler := NewConversionHandler(newTestScheme())
+	raw, _ := json.Marshal(&v1alpha1.Foo{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Foo"},
+	})
+	body, _ := json.Marshal(&ConversionReview{
+		Request: &ConversionRequest{
+			UID:               "test-uid",
+			DesiredAPIVersion: "samplecontroller.k8s.io/v2",
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+
+	resp := &ConversionReview{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Response == nil || resp.Response.Result.Status != metav1.StatusFailure {
+		t.Errorf("expected conversion to fail, got %+v", resp.Response)
+	}
+}
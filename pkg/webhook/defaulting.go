@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/convert"
+)
+
+// NewFooDefaultingHandler returns a handler serving the AdmissionReviews of
+// a MutatingAdmissionWebhook for v1alpha1 Foos. It applies the defaulting
+// functions registered in scheme, so that Foos are stored fully specified, and
+// labels the pods of new Foos that do not label them with v1alpha1.NameLabel.
+func NewFooDefaultingHandler(scheme *runtime.Scheme) http.Handler {
+	d := &fooDefaulter{scheme: scheme}
+	return &admissionHandler{admit: d.admit}
+}
+
+type fooDefaulter struct {
+	scheme *runtime.Scheme
+}
+
+// jsonPatchOperation is a single operation of an RFC 6902 JSON patch.
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (d *fooDefaulter) admit(_ context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return allowed()
+	}
+
+	var original map[string]interface{}
+	if err := json.Unmarshal(request.Object.Raw, &original); err != nil {
+		return badRequest(fmt.Errorf("failed to decode Foo: %w", err))
+	}
+	foo := &v1alpha1.Foo{}
+	if err := json.Unmarshal(request.Object.Raw, foo); err != nil {
+		return badRequest(fmt.Errorf("failed to decode Foo: %w", err))
+	}
+	if foo.Name == "" {
+		foo.Name = request.Name
+	}
+	// Foos created with generateName are only named after admission, so
+	// spec.deploymentName cannot default to their name.
+	if foo.Name == "" && foo.Spec.DeploymentName == "" {
+		allErrs := field.ErrorList{field.Required(field.NewPath("spec", "deploymentName"), "must be set when metadata.generateName is used")}
+		return denied(apierrors.NewInvalid(v1alpha1.SchemeGroupVersion.WithKind("Foo").GroupKind(), foo.GenerateName, allErrs).ErrStatus)
+	}
+	defaulted := foo.DeepCopy()
+	d.scheme.Default(defaulted)
+	// The pods of existing Foos are left as they are, as labeling them would
+	// roll them out.
+	if request.Operation == admissionv1.Create && len(defaulted.Spec.Template.Labels) == 0 && defaulted.Name != "" {
+		defaulted.Spec.Template.Labels = map[string]string{v1alpha1.NameLabel: defaulted.Name}
+	}
+
+	var before, after map[string]interface{}
+	if err := convert.ViaJSON(foo, &before); err != nil {
+		return denied(apierrors.NewInternalError(err).ErrStatus)
+	}
+	if err := convert.ViaJSON(defaulted, &after); err != nil {
+		return denied(apierrors.NewInternalError(err).ErrStatus)
+	}
+	patch := defaultingPatch("", original, before, after)
+	if len(patch) == 0 {
+		return allowed()
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return denied(apierrors.NewInternalError(err).ErrStatus)
+	}
+	patchType := admissionv1.PatchTypeJSONPatch
+	return &admissionv1.AdmissionResponse{Allowed: true, Patch: data, PatchType: &patchType}
+}
+
+// defaultingPatch returns the operations adding the fields set by defaulting
+// to the object as it was sent. before and after are the object decoded
+// before and after defaulting, which both carry the zero values of fields
+// missing from original; comparing them keeps those out of the patch.
+func defaultingPatch(path string, original, before, after interface{}) []jsonPatchOperation {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	originalMap, originalIsMap := original.(map[string]interface{})
+	beforeMap, _ := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if !originalIsMap || !afterIsMap {
+		return []jsonPatchOperation{{Op: "add", Path: path, Value: dropNulls(after)}}
+	}
+
+	keys := make([]string, 0, len(afterMap))
+	for k := range afterMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var patch []jsonPatchOperation
+	for _, k := range keys {
+		patch = append(patch, defaultingPatch(path+"/"+escapeJSONPointer(k), originalMap[k], beforeMap[k], afterMap[k])...)
+	}
+	return patch
+}
+
+// dropNulls removes the fields holding null from the objects in value, which
+// the typed encoding emits for unset fields without omitempty.
+func dropNulls(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			if v != nil {
+				out[k] = dropNulls(v)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, v := range value {
+			out[i] = dropNulls(v)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// escapeJSONPointer escapes a key for use in a JSON pointer as of RFC 6901.
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
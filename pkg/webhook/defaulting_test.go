@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/install"
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+func TestFooDefaultingHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	handler := NewFooDefaultingHandler(scheme)
+
+	testCases := []struct {
+		name      string
+		operation admissionv1.Operation
+		// generateName leaves the name of the request empty, as for Foos
+		// created with metadata.generateName.
+		generateName bool
+		object       string
+		expectedSpec v1alpha1.FooSpec
+		expectPatch  bool
+		expectDenied bool
+	}{
+		{
+			name:   "no spec",
+			object: `{"apiVersion":"samplecontroller.k8s.io/v1alpha1","kind":"Foo","metadata":{"name":"test"}}`,
+			expectedSpec: v1alpha1.FooSpec{
+				DeploymentName: "test",
+				Replicas:       ptr.To[int32](1),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1alpha1.NameLabel: "test"}},
+				},
+				DeletionPolicy: v1alpha1.DeletionPolicyDelete,
+			},
+			expectPatch: true,
+		},
+		{
+			name:   "partial spec",
+			object: `{"apiVersion":"samplecontroller.k8s.io/v1alpha1","kind":"Foo","metadata":{"name":"test"},"spec":{"replicas":3,"template":{"metadata":{"annotations":{"a":"b"}},"spec":{"containers":[{"name":"web","image":"nginx"}]}}}}`,
+			expectedSpec: v1alpha1.FooSpec{
+				DeploymentName: "test",
+				Replicas:       ptr.To[int32](3),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      map[string]string{v1alpha1.NameLabel: "test"},
+						Annotations: map[string]string{"a": "b"},
+					},
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx"}}},
+				},
+				DeletionPolicy: v1alpha1.DeletionPolicyDelete,
+			},
+			expectPatch: true,
+		},
+		{
+			// Labeling the pods of an existing Foo would roll them out.
+			name:      "update without template labels",
+			operation: admissionv1.Update,
+			object:    `{"apiVersion":"samplecontroller.k8s.io/v1alpha1","kind":"Foo","metadata":{"name":"test"},"spec":{"replicas":3}}`,
+			expectedSpec: v1alpha1.FooSpec{
+				DeploymentName: "test",
+				Replicas:       ptr.To[int32](3),
+				DeletionPolicy: v1alpha1.DeletionPolicyDelete,
+			},
+			expectPatch: true,
+		},
+		{
+			name:   "fully specified",
+			object: `{"apiVersion":"samplecontroller.k8s.io/v1alpha1","kind":"Foo","metadata":{"name":"test"},"spec":{"deploymentName":"web","replicas":0,"template":{"metadata":{"labels":{"app":"web"}}},"deletionPolicy":"Retain"}}`,
+			expectedSpec: v1alpha1.FooSpec{
+				DeploymentName: "web",
+				Replicas:       ptr.To[int32](0),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				},
+				DeletionPolicy: v1alpha1.DeletionPolicyRetain,
+			},
+		},
+		{
+			// The pods are labeled with the name of the Foo, which is not
+			// known yet.
+			name:         "generateName",
+			generateName: true,
+			object:       `{"apiVersion":"samplecontroller.k8s.io/v1alpha1","kind":"Foo","metadata":{"generateName":"test-"},"spec":{"deploymentName":"web"}}`,
+			expectedSpec: v1alpha1.FooSpec{
+				DeploymentName: "web",
+				Replicas:       ptr.To[int32](1),
+				DeletionPolicy: v1alpha1.DeletionPolicyDelete,
+			},
+			expectPatch: true,
+		},
+		{
+			name:         "generateName without deploymentName",
+			generateName: true,
+			object:       `{"apiVersion":"samplecontroller.k8s.io/v1alpha1","kind":"Foo","metadata":{"generateName":"test-"}}`,
+			expectDenied: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := tc.operation
+			if operation == "" {
+				operation = admissionv1.Create
+			}
+			name := "test"
+			if tc.generateName {
+				name = ""
+			}
+			resp := admissionReview(t, handler, &admissionv1.AdmissionRequest{
+				Operation: operation,
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Object:    runtime.RawExtension{Raw: []byte(tc.object)},
+			})
+			if tc.expectDenied {
+				if resp.Allowed {
+					t.Fatal("expected the request to be denied")
+				}
+				return
+			}
+			if !resp.Allowed {
+				t.Fatalf("expected the request to be allowed, got %+v", resp.Result)
+			}
+			if !tc.expectPatch {
+				if resp.Patch != nil {
+					t.Fatalf("expected no patch, got %s", resp.Patch)
+				}
+				return
+			}
+			if resp.PatchType == nil || *resp.PatchType != admissionv1.PatchTypeJSONPatch {
+				t.Fatalf("expected a JSON patch, got %v", resp.PatchType)
+			}
+
+			patch, err := jsonpatch.DecodePatch(resp.Patch)
+			if err != nil {
+				t.Fatalf("invalid patch %s: %v", resp.Patch, err)
+			}
+			patched, err := patch.Apply([]byte(tc.object))
+			if err != nil {
+				t.Fatalf("failed to apply patch %s: %v", resp.Patch, err)
+			}
+			foo := &v1alpha1.Foo{}
+			if err := json.Unmarshal(patched, foo); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(foo.Spec, tc.expectedSpec) {
+				t.Errorf("unexpected spec after defaulting:\n%s", diff.ObjectGoPrintSideBySide(tc.expectedSpec, foo.Spec))
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook serves the webhooks of the sample controller over HTTPS.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Server is an HTTPS server that webhook handlers are registered on.
+type Server struct {
+	addr     string
+	certFile string
+	keyFile  string
+	mux      *http.ServeMux
+}
+
+// NewServer returns a Server that will listen on addr and serve the given
+// certificate and private key.
+func NewServer(addr, certFile, keyFile string) *Server {
+	return &Server{
+		addr:     addr,
+		certFile: certFile,
+		keyFile:  keyFile,
+		mux:      http.NewServeMux(),
+	}
+}
+
+// Handle registers the handler for the given path.
+func (s *Server) Handle(path string, handler http.Handler) {
+	s.mux.Handle(path, handler)
+}
+
+// Run serves requests until ctx is cancelled, at which point the server is
+// shut down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "Error shutting down webhook server")
+		}
+	}()
+
+	logger.Info("Starting webhook server", "address", s.addr)
+	if err := srv.ListenAndServeTLS(s.certFile, s.keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
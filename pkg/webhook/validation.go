@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/validation"
+)
+
+// NewFooValidationHandler returns a handler serving the AdmissionReviews of
+// a ValidatingAdmissionWebhook for v1alpha1 Foos. deployments is used to
+// reject Foos naming a Deployment that they do not control.
+func NewFooValidationHandler(deployments appslisters.DeploymentLister) http.Handler {
+	v := &fooValidator{deployments: deployments}
+	return &admissionHandler{admit: v.admit}
+}
+
+type fooValidator struct {
+	deployments appslisters.DeploymentLister
+}
+
+func (v *fooValidator) admit(_ context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return allowed()
+	}
+
+	foo := &v1alpha1.Foo{}
+	if err := json.Unmarshal(request.Object.Raw, foo); err != nil {
+		return badRequest(fmt.Errorf("failed to decode Foo: %w", err))
+	}
+	// Admission requests carry the namespace, which objects being created
+	// may leave out.
+	foo.Namespace = request.Namespace
+
+	var allErrs field.ErrorList
+	checkOwnership := true
+	if request.Operation == admissionv1.Create {
+		allErrs = validation.ValidateFoo(foo)
+	} else {
+		oldFoo := &v1alpha1.Foo{}
+		if err := json.Unmarshal(request.OldObject.Raw, oldFoo); err != nil {
+			return badRequest(fmt.Errorf("failed to decode old Foo: %w", err))
+		}
+		// Foos stored before the defaulting webhook was installed lack the
+		// defaults that it adds to the Foo of their next update.
+		v1alpha1.SetObjectDefaults_Foo(oldFoo)
+		allErrs = validation.ValidateFooUpdate(foo, oldFoo)
+		// Only a new name can clash with a Deployment the Foo does not
+		// control; an existing clash is reported in the Foo's status.
+		checkOwnership = foo.Spec.DeploymentName != oldFoo.Spec.DeploymentName
+	}
+
+	if checkOwnership && len(allErrs) == 0 {
+		deployment, err := v.deployments.Deployments(foo.Namespace).Get(foo.Spec.DeploymentName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return denied(apierrors.NewInternalError(err).ErrStatus)
+		}
+		allErrs = append(allErrs, validation.ValidateDeploymentOwnership(foo, deployment)...)
+	}
+
+	if len(allErrs) > 0 {
+		return denied(apierrors.NewInvalid(v1alpha1.SchemeGroupVersion.WithKind("Foo").GroupKind(), foo.Name, allErrs).ErrStatus)
+	}
+	return allowed()
+}
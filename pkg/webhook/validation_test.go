@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// admissionReview sends a request through an admission handler and returns
+// the response.
+func admissionReview(t *testing.T, handler http.Handler, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	t.Helper()
+	request.UID = "test-uid"
+	body, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  request,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d: %s", w.Code, w.Body.String())
+	}
+
+	resp := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Response == nil || resp.Response.UID != "test-uid" {
+		t.Fatalf("unexpected response: %+v", resp.Response)
+	}
+	return resp.Response
+}
+
+func rawFoo(t *testing.T, foo *v1alpha1.Foo) runtime.RawExtension {
+	t.Helper()
+	raw, err := json.Marshal(foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return runtime.RawExtension{Raw: raw}
+}
+
+func newValidationFoo(deploymentName string) *v1alpha1.Foo {
+	return &v1alpha1.Foo{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Foo"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "foo-uid"},
+		Spec:       v1alpha1.FooSpec{DeploymentName: deploymentName},
+	}
+}
+
+// newPolicyValidationFoo returns a Foo with the given deletion policy, which
+// carries the finalizer of the controller if finalized is set.
+func newPolicyValidationFoo(policy v1alpha1.DeletionPolicy, finalized bool) *v1alpha1.Foo {
+	foo := newValidationFoo("test-deployment")
+	foo.Spec.DeletionPolicy = policy
+	if finalized {
+		foo.Finalizers = []string{v1alpha1.FooFinalizer}
+	}
+	return foo
+}
+
+func TestFooValidationHandler(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "taken", Namespace: metav1.NamespaceDefault}}); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewFooValidationHandler(appslisters.NewDeploymentLister(indexer))
+
+	testCases := []struct {
+		name            string
+		operation       admissionv1.Operation
+		foo             *v1alpha1.Foo
+		oldFoo          *v1alpha1.Foo
+		expectedAllowed bool
+		expectedMessage string
+	}{
+		{
+			name:            "valid create",
+			operation:       admissionv1.Create,
+			foo:             newValidationFoo("test-deployment"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "empty deployment name",
+			operation:       admissionv1.Create,
+			foo:             newValidationFoo(""),
+			expectedMessage: "spec.deploymentName: Required value",
+		},
+		{
+			name:            "invalid deployment name",
+			operation:       admissionv1.Create,
+			foo:             newValidationFoo("Not_A_Name"),
+			expectedMessage: `spec.deploymentName: Invalid value: "Not_A_Name"`,
+		},
+		{
+			name:            "deployment name taken",
+			operation:       admissionv1.Create,
+			foo:             newValidationFoo("taken"),
+			expectedMessage: `spec.deploymentName: Invalid value: "taken": already used by a Deployment that is not controlled by this Foo`,
+		},
+		{
+			name:            "rename to a taken name",
+			operation:       admissionv1.Update,
+			foo:             newValidationFoo("taken"),
+			oldFoo:          newValidationFoo("test-deployment"),
+			expectedMessage: "already used by a Deployment",
+		},
+		{
+			name:            "update keeping a taken name",
+			operation:       admissionv1.Update,
+			foo:             newValidationFoo("taken"),
+			oldFoo:          newValidationFoo("taken"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "change the deletion policy",
+			operation:       admissionv1.Update,
+			foo:             newPolicyValidationFoo(v1alpha1.DeletionPolicyRetain, true),
+			oldFoo:          newPolicyValidationFoo(v1alpha1.DeletionPolicyDelete, true),
+			expectedMessage: `spec.deletionPolicy: Invalid value: "Retain": field is immutable`,
+		},
+		{
+			name:            "change the deletion policy before the finalizer is added",
+			operation:       admissionv1.Update,
+			foo:             newPolicyValidationFoo(v1alpha1.DeletionPolicyRetain, false),
+			oldFoo:          newPolicyValidationFoo(v1alpha1.DeletionPolicyDelete, false),
+			expectedAllowed: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &admissionv1.AdmissionRequest{
+				Operation: tc.operation,
+				Namespace: metav1.NamespaceDefault,
+				Name:      tc.foo.Name,
+				Object:    rawFoo(t, tc.foo),
+			}
+			if tc.oldFoo != nil {
+				request.OldObject = rawFoo(t, tc.oldFoo)
+			}
+			resp := admissionReview(t, handler, request)
+			if resp.Allowed != tc.expectedAllowed {
+				t.Fatalf("expected allowed=%v, got %+v", tc.expectedAllowed, resp)
+			}
+			if tc.expectedAllowed {
+				return
+			}
+			if resp.Result == nil || resp.Result.Code != http.StatusUnprocessableEntity || !strings.Contains(resp.Result.Message, tc.expectedMessage) {
+				t.Errorf("expected an Invalid result containing %q, got %+v", tc.expectedMessage, resp.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+)
+
+// rateLimiter delays the retries of a Foo by the larger of its exponential
+// backoff and the delay imposed by an overall token bucket, like the default
+// controller rate limiter of the workqueue package. Unlike that one, its
+// settings can be changed while the workqueue is in use, without forgetting
+// the failures of the Foos being retried.
+type rateLimiter struct {
+	lock      sync.Mutex
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	failures  map[cache.ObjectName]int
+
+	bucket *rate.Limiter
+}
+
+var _ workqueue.TypedRateLimiter[cache.ObjectName] = &rateLimiter{}
+
+func newRateLimiter(cfg *config.RateLimiterConfiguration) *rateLimiter {
+	r := &rateLimiter{
+		failures: map[cache.ObjectName]int{},
+		bucket:   rate.NewLimiter(rate.Limit(cfg.QPS), int(cfg.Burst)),
+	}
+	r.configure(cfg)
+	return r
+}
+
+// configure applies cfg. Delays already handed out are not changed.
+func (r *rateLimiter) configure(cfg *config.RateLimiterConfiguration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.baseDelay = cfg.BaseDelay.Duration
+	r.maxDelay = cfg.MaxDelay.Duration
+	r.bucket.SetLimit(rate.Limit(cfg.QPS))
+	r.bucket.SetBurst(int(cfg.Burst))
+}
+
+func (r *rateLimiter) When(item cache.ObjectName) time.Duration {
+	r.lock.Lock()
+	exp := r.failures[item]
+	r.failures[item] = exp + 1
+	// The backoff is computed in floating point so that it saturates at
+	// maxDelay instead of overflowing.
+	backoff := float64(r.baseDelay.Nanoseconds()) * math.Pow(2, float64(exp))
+	delay := r.maxDelay
+	if backoff < float64(r.maxDelay.Nanoseconds()) {
+		delay = time.Duration(backoff)
+	}
+	r.lock.Unlock()
+
+	return max(delay, r.bucket.Reserve().Delay())
+}
+
+func (r *rateLimiter) Forget(item cache.ObjectName) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.failures, item)
+}
+
+func (r *rateLimiter) NumRequeues(item cache.ObjectName) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.failures[item]
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+	"k8s.io/sample-controller/pkg/metrics"
+)
+
+const (
+	// ConfigurationReloaded is used as part of the Event 'reason' when a
+	// reloaded configuration is applied
+	ConfigurationReloaded = "ConfigurationReloaded"
+	// ErrConfigurationRejected is used as part of the Event 'reason' when a
+	// reloaded configuration is rejected
+	ErrConfigurationRejected = "ErrConfigurationRejected"
+	// MessageConfigurationReloaded is the message used for Events when a
+	// reloaded configuration is applied
+	MessageConfigurationReloaded = "Configuration reloaded"
+	// MessageConfigurationRejected is the message used for Events when a
+	// reloaded configuration is rejected
+	MessageConfigurationRejected = "Configuration reload rejected, keeping the previous configuration: %v"
+)
+
+// startWorkers runs the configured number of workers until ctx is
+// cancelled.
+func (c *Controller) startWorkers(ctx context.Context) {
+	c.workersLock.Lock()
+	defer c.workersLock.Unlock()
+	klog.FromContext(ctx).Info("Starting workers", "count", c.workers)
+	c.workersCtx = ctx
+	c.resizeWorkers()
+}
+
+// setWorkers changes the number of workers. If they are running, workers
+// are started or retired right away; a retired worker finishes the Foo it is
+// syncing first.
+func (c *Controller) setWorkers(n int) {
+	c.workersLock.Lock()
+	defer c.workersLock.Unlock()
+	c.workers = n
+	if c.workersCtx != nil {
+		c.resizeWorkers()
+	}
+}
+
+func (c *Controller) resizeWorkers() {
+	for len(c.workerCancels) < c.workers {
+		// The syncs run with the context of Run, so that retiring a worker
+		// does not abort the sync it is running.
+		ctx := c.workersCtx
+		workerCtx, cancel := context.WithCancel(ctx)
+		c.workerCancels = append(c.workerCancels, cancel)
+		go wait.UntilWithContext(workerCtx, func(workerCtx context.Context) {
+			c.runWorker(ctx, workerCtx.Done())
+		}, time.Second)
+	}
+	for len(c.workerCancels) > c.workers {
+		last := len(c.workerCancels) - 1
+		c.workerCancels[last]()
+		c.workerCancels = c.workerCancels[:last]
+	}
+}
+
+// reloadOnSignal reloads the configuration from path whenever a value is
+// received from reloads, until ctx is cancelled. Flags given in fs override
+// the file, as they did at startup.
+func (c *Controller) reloadOnSignal(ctx context.Context, reloads <-chan os.Signal, path string, fs *flag.FlagSet) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloads:
+			c.reloadConfiguration(ctx, path, fs)
+		}
+	}
+}
+
+// reloadConfiguration reads the configuration again and applies it, and
+// reports whether it was applied through an Event and a metric. A rejected
+// configuration leaves the one in effect untouched.
+func (c *Controller) reloadConfiguration(ctx context.Context, path string, fs *flag.FlagSet) {
+	logger := klog.FromContext(ctx)
+	logger.Info("Reloading configuration", "path", path)
+
+	cfg, err := loadConfiguration(path, fs)
+	if err == nil {
+		err = c.applyConfiguration(ctx, cfg)
+	}
+	if err != nil {
+		logger.Error(err, "Rejected configuration reload", "path", path)
+		metrics.ObserveConfigReload(metrics.ReloadRejected)
+		c.recordReload(corev1.EventTypeWarning, ErrConfigurationRejected, fmt.Sprintf(MessageConfigurationRejected, err))
+		return
+	}
+	logger.Info("Reloaded configuration", "path", path)
+	metrics.ObserveConfigReload(metrics.ReloadApplied)
+	c.recordReload(corev1.EventTypeNormal, ConfigurationReloaded, MessageConfigurationReloaded)
+}
+
+func (c *Controller) recordReload(eventtype, reason, message string) {
+	if c.reloadTarget != nil {
+		c.recorder.Eventf(c.reloadTarget, nil, eventtype, reason, ActionReload, "%s", message)
+	}
+}
+
+// applyConfiguration puts the settings of cfg that can change while the
+// controller runs into effect: the log verbosity, the number of workers, the
+// rate limiter and the suspended namespaces. It fails without changing
+// anything if cfg changes any other setting, as those need a restart.
+func (c *Controller) applyConfiguration(ctx context.Context, cfg *config.SampleControllerConfiguration) error {
+	c.reloadLock.Lock()
+	defer c.reloadLock.Unlock()
+
+	if changed := restartRequired(c.config, cfg); len(changed) > 0 {
+		return fmt.Errorf("changing %s requires a restart", strings.Join(changed, ", "))
+	}
+	if cfg.LogVerbosity != c.config.LogVerbosity {
+		if err := setLogVerbosity(cfg.LogVerbosity); err != nil {
+			return err
+		}
+	}
+	c.setWorkers(int(cfg.Workers))
+	c.rateLimiter.configure(&cfg.RateLimiter)
+
+	// The Foos of namespaces that are suspended or resumed are synced right
+	// away, rather than at their next change.
+	suspended := sets.New(cfg.SuspendedNamespaces...)
+	previous := c.suspendedNamespaces.Swap(ptr.To(suspended))
+	if previous != nil {
+		for namespace := range previous.SymmetricDifference(suspended) {
+			c.enqueueNamespace(ctx, namespace)
+		}
+	}
+
+	c.config = cfg
+	return nil
+}
+
+// restartRequired returns the settings that differ between old and new and
+// cannot be changed while the controller runs, as the informers were set up
+// from them.
+func restartRequired(old, new *config.SampleControllerConfiguration) []string {
+	var changed []string
+	for _, setting := range []struct {
+		name     string
+		old, new interface{}
+	}{
+		{"resyncPeriod", old.ResyncPeriod, new.ResyncPeriod},
+		{"namespaces", old.Namespaces, new.Namespaces},
+		{"namespaceSelector", old.NamespaceSelector, new.NamespaceSelector},
+		{"fooSelector", old.FooSelector, new.FooSelector},
+		{"events", old.Events, new.Events},
+	} {
+		if !reflect.DeepEqual(setting.old, setting.new) {
+			changed = append(changed, setting.name)
+		}
+	}
+	return changed
+}
+
+// enqueueNamespace enqueues every Foo in namespace.
+func (c *Controller) enqueueNamespace(ctx context.Context, namespace string) {
+	foos, err := c.foosLister.Foos(namespace).List(labels.Everything())
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to list Foos", "namespace", namespace)
+		return
+	}
+	for _, foo := range foos {
+		c.enqueueFoo(foo)
+	}
+}
+
+// setLogVerbosity changes the verbosity of klog, like -v does.
+func setLogVerbosity(v int32) error {
+	var level klog.Level
+	return level.Set(strconv.Itoa(int(v)))
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2/ktesting"
+
+	"k8s.io/sample-controller/pkg/apis/config"
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// newReloadController returns a controller of f whose reload Events are
+// recorded by the returned recorder.
+func newReloadController(ctx context.Context, f *fixture) (*Controller, *events.FakeRecorder) {
+	c, i, k8sI := f.newController(ctx)
+	recorder := events.NewFakeRecorder(1)
+	c.recorder = recorder
+	c.reloadTarget = &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: "sample-controller", Namespace: "kube-system"}
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+	return c, recorder
+}
+
+func expectEvent(t *testing.T, recorder *events.FakeRecorder, reason string) {
+	t.Helper()
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, reason) {
+			t.Errorf("expected a %s event, got %q", reason, event)
+		}
+	default:
+		t.Errorf("expected a %s event", reason)
+	}
+}
+
+func TestReloadConfiguration(t *testing.T) {
+	f := newFixture(t)
+	_, ctx := ktesting.NewTestContext(t)
+	foo := newFoo("test", int32Ptr(1))
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	c, recorder := newReloadController(ctx, f)
+
+	path := writeConfigFile(t, `apiVersion: config.samplecontroller.k8s.io/v1alpha1
+kind: SampleControllerConfiguration
+workers: 4
+rateLimiter:
+  baseDelay: 1s
+suspendedNamespaces: [`+metav1.NamespaceDefault+`]
+`)
+	c.reloadConfiguration(ctx, path, newConfigFlagSet(t, "--workers=3"))
+	expectEvent(t, recorder, ConfigurationReloaded)
+
+	if c.workers != 3 {
+		t.Errorf("expected the workers flag to win over the file, got %d workers", c.workers)
+	}
+	if delay := c.rateLimiter.When(cache.ObjectName{Name: "other"}); delay != time.Second {
+		t.Errorf("expected the new base delay, got %v", delay)
+	}
+	if cond := c.fooSuspendedCondition(foo); cond.Reason != ReasonNamespaceSuspended {
+		t.Errorf("expected the Foo to be suspended by its namespace, got %+v", cond)
+	}
+	// The Foos of the suspended namespace are synced again right away.
+	if n := c.workqueue.Len(); n != 1 {
+		t.Errorf("expected the Foo to be enqueued, got %d items", n)
+	}
+}
+
+func TestReloadConfigurationRejected(t *testing.T) {
+	testCases := map[string]struct {
+		content  string
+		expected string
+	}{
+		"invalid": {
+			content: `apiVersion: config.samplecontroller.k8s.io/v1alpha1
+kind: SampleControllerConfiguration
+workers: 4
+rateLimiter:
+  qps: -1
+`,
+			expected: "rateLimiter.qps",
+		},
+		"restart required": {
+			content: `apiVersion: config.samplecontroller.k8s.io/v1alpha1
+kind: SampleControllerConfiguration
+workers: 4
+resyncPeriod: 1m
+fooSelector: shard=1
+`,
+			expected: "changing resyncPeriod, fooSelector requires a restart",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture(t)
+			_, ctx := ktesting.NewTestContext(t)
+			c, recorder := newReloadController(ctx, f)
+			previous := c.config
+
+			c.reloadConfiguration(ctx, writeConfigFile(t, tc.content), newConfigFlagSet(t))
+			select {
+			case event := <-recorder.Events:
+				if !strings.Contains(event, ErrConfigurationRejected) || !strings.Contains(event, tc.expected) {
+					t.Errorf("unexpected event %q", event)
+				}
+			default:
+				t.Error("expected an event")
+			}
+			if c.config != previous || c.workers != int(previous.Workers) {
+				t.Errorf("expected the previous configuration to stay in effect")
+			}
+		})
+	}
+}
+
+func TestSetWorkers(t *testing.T) {
+	f := newFixture(t)
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c, _ := newReloadController(ctx, f)
+
+	c.setWorkers(1)
+	if len(c.workerCancels) != 0 {
+		t.Fatalf("expected no workers before they are started, got %d", len(c.workerCancels))
+	}
+	c.startWorkers(ctx)
+	for _, n := range []int{1, 4, 2} {
+		c.setWorkers(n)
+		if len(c.workerCancels) != n {
+			t.Errorf("expected %d workers, got %d", n, len(c.workerCancels))
+		}
+	}
+}
+
+func TestRateLimiterConfigure(t *testing.T) {
+	cfg := &config.RateLimiterConfiguration{
+		BaseDelay: metav1.Duration{Duration: time.Millisecond},
+		MaxDelay:  metav1.Duration{Duration: time.Second},
+		QPS:       1000,
+		Burst:     1000,
+	}
+	r := newRateLimiter(cfg)
+	item := cache.ObjectName{Namespace: "default", Name: "test"}
+	for _, expected := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond} {
+		if delay := r.When(item); delay != expected {
+			t.Errorf("expected a delay of %v, got %v", expected, delay)
+		}
+	}
+
+	// The failures of the item are kept, so its backoff carries on from
+	// the new base delay.
+	cfg.BaseDelay.Duration = 10 * time.Millisecond
+	r.configure(cfg)
+	if delay := r.When(item); delay != 80*time.Millisecond {
+		t.Errorf("expected a delay of 80ms, got %v", delay)
+	}
+	cfg.MaxDelay.Duration = 50 * time.Millisecond
+	r.configure(cfg)
+	if delay := r.When(item); delay != 50*time.Millisecond {
+		t.Errorf("expected the delay to be capped at 50ms, got %v", delay)
+	}
+	if n := r.NumRequeues(item); n != 5 {
+		t.Errorf("expected 5 requeues, got %d", n)
+	}
+	r.Forget(item)
+	if delay := r.When(item); delay != 10*time.Millisecond {
+		t.Errorf("expected a forgotten item to start over, got %v", delay)
+	}
+}
+
+func TestNamespaceSuspendedCondition(t *testing.T) {
+	f := newFixture(t)
+	_, ctx := ktesting.NewTestContext(t)
+	c, _ := newReloadController(ctx, f)
+	cfg := *c.config
+	cfg.SuspendedNamespaces = []string{"team-a"}
+	if err := c.applyConfiguration(ctx, &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	foo := newFoo("test", int32Ptr(1))
+	if cond := c.fooSuspendedCondition(foo); cond.Status != metav1.ConditionFalse {
+		t.Errorf("expected a Foo of another namespace not to be suspended, got %+v", cond)
+	}
+	foo.Namespace = "team-a"
+	if cond := c.fooSuspendedCondition(foo); cond.Reason != ReasonNamespaceSuspended {
+		t.Errorf("expected the Foo to be suspended by its namespace, got %+v", cond)
+	}
+	// The spec of the Foo takes precedence in the reported reason.
+	foo.Annotations = map[string]string{samplecontroller.PausedAnnotation: "true"}
+	if cond := c.fooSuspendedCondition(foo); cond.Reason != ReasonPausedByAnnotation {
+		t.Errorf("expected the annotation to be reported, got %+v", cond)
+	}
+}
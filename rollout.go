@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+const (
+	// RolledBack is used as part of the Event 'reason' when the controller
+	// restores the last good pod template of a Foo after a failed rollout
+	RolledBack = "RolledBack"
+	// ErrRolloutFailed is used as part of the Event 'reason' when the
+	// rollout of a Foo exceeded its progress deadline and is not rolled back
+	ErrRolloutFailed = "ErrRolloutFailed"
+
+	// MessageRolledBack is the message used for Events and the RolledBack
+	// condition when a failed revision is rolled back
+	MessageRolledBack = "Rolled back failed revision %s of Deployment %q to the template of revision %s"
+	// MessageRolloutFailed is the message used for Events when a rollout
+	// failed and is not rolled back
+	MessageRolloutFailed = "Rollout of revision %s of Deployment %q failed: %s"
+
+	// deploymentRevisionAnnotation is set on Deployments by the deployment
+	// controller and numbers the revisions of their pod template.
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// progressDeadlineExceededReason is the reason of the Progressing
+	// condition of a Deployment whose rollout exceeded its progress deadline.
+	progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+)
+
+// Reasons used for the RolledBack condition.
+const (
+	// ReasonRolloutFailed is used when the Deployment runs the last good pod
+	// template because the rollout of the current one failed.
+	ReasonRolloutFailed = "RolloutFailed"
+)
+
+// templateHash identifies the pod template of a Deployment built by
+// newDeployment, before any rollback.
+func templateHash(deployment *appsv1.Deployment) string {
+	return computeHash(deployment.Spec.Template)
+}
+
+// rollbackTemplate replaces the pod template of desired with the last good
+// template of foo if the current template, identified by hash, failed to
+// roll out and foo is rolled back automatically. It reports whether it did.
+func rollbackTemplate(foo *samplev1alpha1.Foo, desired *appsv1.Deployment, hash string) bool {
+	rollout := foo.Status.Rollout
+	if foo.Spec.Rollback == nil || !foo.Spec.Rollback.Automatic ||
+		rollout == nil || rollout.LastGoodTemplate == nil || rollout.FailedTemplateHash != hash {
+		return false
+	}
+	desired.Spec.Template = *rollout.LastGoodTemplate.DeepCopy()
+	desired.Annotations[DesiredStateHashAnnotation] = desiredStateHash(foo, desired.Spec)
+	return true
+}
+
+// trackRollout records the pod template of desired, identified by hash, in
+// the status of foo once deployment runs it on all of its replicas. When the
+// rollout of the template exceeds its progress deadline instead, and foo is
+// rolled back automatically, the last good template is applied. It returns
+// the Deployment and whether it runs the last good template instead of the
+// one of foo.
+func (c *Controller) trackRollout(ctx context.Context, foo *samplev1alpha1.Foo, desired, deployment *appsv1.Deployment, hash string, rolledBack bool) (*appsv1.Deployment, bool, error) {
+	if rolledBack {
+		return deployment, true, nil
+	}
+	revision := deployment.Annotations[deploymentRevisionAnnotation]
+
+	if deploymentRolledOut(deployment) {
+		foo.Status.Rollout = &samplev1alpha1.FooRolloutStatus{
+			LastGoodRevision:     revision,
+			LastGoodTemplateHash: hash,
+			LastGoodTemplate:     desired.Spec.Template.DeepCopy(),
+		}
+		return deployment, false, nil
+	}
+	if !progressDeadlineExceeded(deployment) {
+		return deployment, false, nil
+	}
+
+	rollout := foo.Status.Rollout
+	if foo.Spec.Rollback == nil || !foo.Spec.Rollback.Automatic ||
+		rollout == nil || rollout.LastGoodTemplate == nil || rollout.LastGoodTemplateHash == hash {
+		return deployment, false, nil
+	}
+	rollout.FailedRevision = revision
+	rollout.FailedTemplateHash = hash
+	rollbackTemplate(foo, desired, hash)
+
+	klog.FromContext(ctx).V(4).Info("Rolling back deployment", "deployment", klog.KObj(deployment), "failedRevision", revision, "revision", rollout.LastGoodRevision)
+	deployment, err := c.applyDeployment(ctx, desired, true)
+	if err != nil {
+		return nil, false, err
+	}
+	c.recorder.Eventf(foo, deployment, corev1.EventTypeWarning, RolledBack, ActionRollout, MessageRolledBack, revision, deployment.Name, rollout.LastGoodRevision)
+	return deployment, true, nil
+}
+
+// rolledBackCondition returns the RolledBack condition of foo, whose
+// Deployment runs the last good pod template if rolledBack is set.
+func rolledBackCondition(foo *samplev1alpha1.Foo, rolledBack bool) metav1.Condition {
+	if !rolledBack {
+		return metav1.Condition{
+			Type:   samplev1alpha1.FooRolledBack,
+			Status: metav1.ConditionFalse,
+			Reason: ReasonAsExpected,
+		}
+	}
+	rollout := foo.Status.Rollout
+	return metav1.Condition{
+		Type:    samplev1alpha1.FooRolledBack,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonRolloutFailed,
+		Message: fmt.Sprintf(MessageRolledBack, rollout.FailedRevision, foo.Spec.DeploymentName, rollout.LastGoodRevision),
+	}
+}
+
+// progressDeadlineExceeded reports whether the Deployment gave up rolling out
+// its current pod template.
+func progressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	cond := getDeploymentCondition(deployment.Status, appsv1.DeploymentProgressing)
+	return cond != nil && cond.Status == corev1.ConditionFalse && cond.Reason == progressDeadlineExceededReason
+}
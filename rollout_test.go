@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2/ktesting"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	samplescheme "k8s.io/sample-controller/pkg/generated/clientset/versioned/scheme"
+)
+
+// newFailedRolloutFoo returns a Foo whose template was changed to an image
+// that does not roll out, along with the Deployment of its previous, good
+// template and the Deployment that exceeded its progress deadline.
+func newFailedRolloutFoo(automatic bool) (*samplecontroller.Foo, *apps.Deployment, *apps.Deployment) {
+	foo := newFoo("test", int32Ptr(1))
+	good := newDeployment(foo, "")
+	foo.Status.Rollout = &samplecontroller.FooRolloutStatus{
+		LastGoodRevision:     "1",
+		LastGoodTemplateHash: templateHash(good),
+		LastGoodTemplate:     &good.Spec.Template,
+	}
+	foo.Spec.Rollback = &samplecontroller.FooRollback{Automatic: automatic}
+	foo.Spec.Template.Spec.Containers = []corev1.Container{{Name: "nginx", Image: "nginx:broken"}}
+	samplescheme.Scheme.Default(foo)
+
+	failed := newDeployment(foo, "")
+	failed.Annotations[deploymentRevisionAnnotation] = "2"
+	failed.Status.Conditions = []apps.DeploymentCondition{{
+		Type:    apps.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Reason:  progressDeadlineExceededReason,
+		Message: `ReplicaSet "test-deployment-2" has timed out progressing.`,
+	}}
+	return foo, good, failed
+}
+
+func TestRecordsLastGoodRevision(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.Annotations[deploymentRevisionAnnotation] = "3"
+	d.Status = apps.DeploymentStatus{
+		Replicas:          1,
+		UpdatedReplicas:   1,
+		ReadyReplicas:     1,
+		AvailableReplicas: 1,
+	}
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	status := readyStatus(foo)
+	status.Rollout.LastGoodRevision = "3"
+	f.expectApplyFooStatusAction(withStatus(foo, status))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestRollsBackFailedRollout(t *testing.T) {
+	f := newFixture(t)
+	foo, good, failed := newFailedRolloutFoo(true)
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, failed)
+	f.kubeobjects = append(f.kubeobjects, failed)
+
+	c, i, k8sI := f.newController(ctx)
+	recorder := events.NewFakeRecorder(2)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatalf("error syncing foo: %v", err)
+	}
+
+	d, err := f.kubeclient.AppsV1().Deployments(failed.Namespace).Get(ctx, failed.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := d.Spec.Template.Spec.Containers[0].Image; image != good.Spec.Template.Spec.Containers[0].Image {
+		t.Errorf("expected the good template to be restored, got image %q", image)
+	}
+
+	updated, err := f.client.SamplecontrollerV1alpha1().Foos(foo.Namespace).Get(ctx, foo.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rollout := updated.Status.Rollout; rollout == nil || rollout.FailedRevision != "2" || rollout.FailedTemplateHash != templateHash(failed) || rollout.LastGoodRevision != "1" {
+		t.Errorf("expected the failed revision to be recorded, got %+v", rollout)
+	}
+	expected := fmt.Sprintf(MessageRolledBack, "2", failed.Name, "1")
+	cond := meta.FindStatusCondition(updated.Status.Conditions, samplecontroller.FooRolledBack)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ReasonRolloutFailed || cond.Message != expected {
+		t.Errorf("expected %s condition to be set, got %+v", samplecontroller.FooRolledBack, cond)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, RolledBack) || !strings.Contains(event, expected) {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event")
+	}
+}
+
+func TestKeepsRolledBackTemplate(t *testing.T) {
+	f := newFixture(t)
+	foo, _, failed := newFailedRolloutFoo(true)
+	foo.Status.Rollout.FailedRevision = "2"
+	foo.Status.Rollout.FailedTemplateHash = templateHash(failed)
+	_, ctx := ktesting.NewTestContext(t)
+
+	// The Deployment already runs the good template again.
+	d := newDeployment(foo, "")
+	rollbackTemplate(foo, d, templateHash(failed))
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	status := rollingOutStatus(foo)
+	status.Rollout = foo.Status.Rollout
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               samplecontroller.FooRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonRolloutFailed,
+		Message:            fmt.Sprintf(MessageRolledBack, "2", d.Name, "1"),
+		LastTransitionTime: syncTime,
+	})
+	f.expectApplyFooStatusAction(withStatus(foo, status))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestReportsFailedRolloutWithoutRollback(t *testing.T) {
+	f := newFixture(t)
+	foo, _, failed := newFailedRolloutFoo(false)
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, failed)
+	f.kubeobjects = append(f.kubeobjects, failed)
+
+	c, i, k8sI := f.newController(ctx)
+	recorder := events.NewFakeRecorder(2)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatalf("error syncing foo: %v", err)
+	}
+	for _, action := range filterInformerActions(f.kubeclient.Actions()) {
+		if action.Matches("patch", "deployments") {
+			t.Errorf("expected the failed Deployment to be left alone, got %+v", action)
+		}
+	}
+
+	updated, err := f.client.SamplecontrollerV1alpha1().Foos(foo.Namespace).Get(ctx, foo.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, samplecontroller.FooDegraded) {
+		t.Errorf("expected Foo to be degraded, got %+v", updated.Status.Conditions)
+	}
+	if !meta.IsStatusConditionFalse(updated.Status.Conditions, samplecontroller.FooRolledBack) {
+		t.Errorf("expected Foo not to be rolled back, got %+v", updated.Status.Conditions)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, ErrRolloutFailed) || !strings.Contains(event, "revision 2") {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event")
+	}
+}
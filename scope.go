@@ -0,0 +1,411 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	clientset "k8s.io/sample-controller/pkg/generated/clientset/versioned"
+	informers "k8s.io/sample-controller/pkg/generated/informers/externalversions"
+)
+
+// informerScope holds the informer factories of the namespaces the controller
+// watches. A single namespace of metav1.NamespaceAll watches the whole
+// cluster; otherwise each namespace gets factories of its own, so that the
+// controller only needs permissions in those namespaces. Namespaces can be
+// added and removed while the controller runs.
+type informerScope struct {
+	kubeClient   kubernetes.Interface
+	sampleClient clientset.Interface
+	resync       time.Duration
+
+	lock       sync.RWMutex
+	namespaces map[string]*namespaceInformers
+	// watchers are called for the informers of every namespace before they
+	// are started.
+	watchers []func(*namespaceInformers)
+	// syncedHandlers are called for the informers of every namespace added
+	// after the start, once they have synced.
+	syncedHandlers []func(*namespaceInformers)
+	// ctx is set once the scope is started. The informers of namespaces
+	// added later are started right away.
+	ctx context.Context
+}
+
+// namespaceInformers are the informer factories of a single namespace.
+type namespaceInformers struct {
+	namespace string
+	kube      kubeinformers.SharedInformerFactory
+	sample    informers.SharedInformerFactory
+	cancel    context.CancelFunc
+	// synced is set once the informers have synced. The informers of the
+	// namespaces added before the start are waited for by the controller
+	// before it runs its workers, so those are set right away.
+	synced atomic.Bool
+}
+
+func (n *namespaceInformers) deployments() cache.SharedIndexInformer {
+	return n.kube.Apps().V1().Deployments().Informer()
+}
+
+func (n *namespaceInformers) services() cache.SharedIndexInformer {
+	return n.kube.Core().V1().Services().Informer()
+}
+
+func (n *namespaceInformers) podDisruptionBudgets() cache.SharedIndexInformer {
+	return n.kube.Policy().V1().PodDisruptionBudgets().Informer()
+}
+
+func (n *namespaceInformers) horizontalPodAutoscalers() cache.SharedIndexInformer {
+	return n.kube.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+}
+
+func (n *namespaceInformers) configMaps() cache.SharedIndexInformer {
+	return n.kube.Core().V1().ConfigMaps().Informer()
+}
+
+func (n *namespaceInformers) secrets() cache.SharedIndexInformer {
+	return n.kube.Core().V1().Secrets().Informer()
+}
+
+func (n *namespaceInformers) foos() cache.SharedIndexInformer {
+	return n.sample.Samplecontroller().V1alpha1().Foos().Informer()
+}
+
+// newInformerScope returns a scope without any namespace.
+func newInformerScope(kubeClient kubernetes.Interface, sampleClient clientset.Interface, resync time.Duration) *informerScope {
+	return &informerScope{
+		kubeClient:   kubeClient,
+		sampleClient: sampleClient,
+		resync:       resync,
+		namespaces:   map[string]*namespaceInformers{},
+	}
+}
+
+// addNamespace starts watching namespace, unless it is watched already.
+func (s *informerScope) addNamespace(namespace string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.namespaces[namespace]; ok {
+		return
+	}
+	n := &namespaceInformers{
+		namespace: namespace,
+		kube:      kubeinformers.NewSharedInformerFactoryWithOptions(s.kubeClient, s.resync, kubeinformers.WithNamespace(namespace)),
+		sample:    informers.NewSharedInformerFactoryWithOptions(s.sampleClient, s.resync, informers.WithNamespace(namespace)),
+	}
+	for _, watch := range s.watchers {
+		watch(n)
+	}
+	s.namespaces[namespace] = n
+	if s.ctx == nil {
+		n.synced.Store(true)
+		return
+	}
+	go s.waitForSync(s.startNamespace(n), n)
+}
+
+// removeNamespace stops watching namespace. The objects in it are left as
+// they are.
+func (s *informerScope) removeNamespace(namespace string) {
+	s.lock.Lock()
+	n, ok := s.namespaces[namespace]
+	delete(s.namespaces, namespace)
+	s.lock.Unlock()
+	if !ok || n.cancel == nil {
+		return
+	}
+	n.cancel()
+	n.kube.Shutdown()
+	n.sample.Shutdown()
+}
+
+// watch calls fn for the informers of every namespace in the scope, current
+// and future, before they are started.
+func (s *informerScope) watch(fn func(*namespaceInformers)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.watchers = append(s.watchers, fn)
+	for _, n := range s.namespaces {
+		fn(n)
+	}
+}
+
+// onSynced calls fn for the informers of every namespace added after the
+// start, once they have synced.
+func (s *informerScope) onSynced(fn func(*namespaceInformers)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.syncedHandlers = append(s.syncedHandlers, fn)
+}
+
+// start starts the informers of all namespaces, and of the namespaces added
+// later, until ctx is cancelled.
+func (s *informerScope) start(ctx context.Context) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.ctx = ctx
+	for _, n := range s.namespaces {
+		s.startNamespace(n)
+	}
+}
+
+// startNamespace starts the informers of n, returning the context they run
+// with.
+func (s *informerScope) startNamespace(n *namespaceInformers) context.Context {
+	var ctx context.Context
+	ctx, n.cancel = context.WithCancel(s.ctx)
+	klog.FromContext(ctx).Info("Watching namespace", "namespace", n.namespace)
+	n.kube.Start(ctx.Done())
+	n.sample.Start(ctx.Done())
+	return ctx
+}
+
+// waitForSync marks n synced once its informers have synced, and calls the
+// handlers registered with onSynced. It gives up when ctx is cancelled.
+func (s *informerScope) waitForSync(ctx context.Context, n *namespaceInformers) {
+	for _, synced := range []map[reflect.Type]bool{n.kube.WaitForCacheSync(ctx.Done()), n.sample.WaitForCacheSync(ctx.Done())} {
+		for _, ok := range synced {
+			if !ok {
+				return
+			}
+		}
+	}
+	n.synced.Store(true)
+	klog.FromContext(ctx).Info("Synced namespace", "namespace", n.namespace)
+
+	s.lock.RLock()
+	handlers := s.syncedHandlers
+	s.lock.RUnlock()
+	for _, handler := range handlers {
+		handler(n)
+	}
+}
+
+// namespaceSynced reports whether the informers of namespace have synced.
+func (s *informerScope) namespaceSynced(namespace string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	n, ok := s.namespaces[namespace]
+	if !ok {
+		n, ok = s.namespaces[metav1.NamespaceAll]
+	}
+	return ok && n.synced.Load()
+}
+
+// hasSynced returns an InformerSynced reporting whether the informer chosen
+// by informer has synced in every namespace.
+func (s *informerScope) hasSynced(informer func(*namespaceInformers) cache.SharedIndexInformer) cache.InformerSynced {
+	return func() bool {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		for _, n := range s.namespaces {
+			if !informer(n).HasSynced() {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// indexer returns a read-only Indexer over the caches of the informer chosen
+// by informer in every namespace, on which listers can be built.
+func (s *informerScope) indexer(informer func(*namespaceInformers) cache.SharedIndexInformer) cache.Indexer {
+	return &scopeIndexer{scope: s, informer: informer}
+}
+
+// indexers returns the indexers of the chosen informer in every namespace.
+func (s *informerScope) indexers(informer func(*namespaceInformers) cache.SharedIndexInformer) []cache.Indexer {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	indexers := make([]cache.Indexer, 0, len(s.namespaces))
+	for _, n := range s.namespaces {
+		indexers = append(indexers, informer(n).GetIndexer())
+	}
+	return indexers
+}
+
+// scopeIndexer merges the caches of an informerScope. Objects are only ever
+// added to them by their informers, so it cannot be written to.
+type scopeIndexer struct {
+	scope    *informerScope
+	informer func(*namespaceInformers) cache.SharedIndexInformer
+}
+
+var _ cache.Indexer = &scopeIndexer{}
+
+var errReadOnlyIndexer = fmt.Errorf("the indexer of an informer scope is read-only")
+
+func (i *scopeIndexer) Add(interface{}) error               { return errReadOnlyIndexer }
+func (i *scopeIndexer) Update(interface{}) error            { return errReadOnlyIndexer }
+func (i *scopeIndexer) Delete(interface{}) error            { return errReadOnlyIndexer }
+func (i *scopeIndexer) Replace([]interface{}, string) error { return errReadOnlyIndexer }
+func (i *scopeIndexer) Resync() error                       { return nil }
+func (i *scopeIndexer) AddIndexers(cache.Indexers) error    { return errReadOnlyIndexer }
+
+func (i *scopeIndexer) List() []interface{} {
+	var items []interface{}
+	for _, indexer := range i.scope.indexers(i.informer) {
+		items = append(items, indexer.List()...)
+	}
+	return items
+}
+
+func (i *scopeIndexer) ListKeys() []string {
+	var keys []string
+	for _, indexer := range i.scope.indexers(i.informer) {
+		keys = append(keys, indexer.ListKeys()...)
+	}
+	return keys
+}
+
+func (i *scopeIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return i.GetByKey(key)
+}
+
+// GetByKey looks key up in the caches of every namespace. There is at most
+// one that can hold it, as the namespaces of a scope do not overlap.
+func (i *scopeIndexer) GetByKey(key string) (interface{}, bool, error) {
+	for _, indexer := range i.scope.indexers(i.informer) {
+		item, exists, err := indexer.GetByKey(key)
+		if err != nil || exists {
+			return item, exists, err
+		}
+	}
+	return nil, false, nil
+}
+
+func (i *scopeIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	var items []interface{}
+	for _, indexer := range i.scope.indexers(i.informer) {
+		found, err := indexer.Index(indexName, obj)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+func (i *scopeIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	var keys []string
+	for _, indexer := range i.scope.indexers(i.informer) {
+		found, err := indexer.IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, found...)
+	}
+	return keys, nil
+}
+
+func (i *scopeIndexer) ListIndexFuncValues(indexName string) []string {
+	var values []string
+	for _, indexer := range i.scope.indexers(i.informer) {
+		values = append(values, indexer.ListIndexFuncValues(indexName)...)
+	}
+	return values
+}
+
+func (i *scopeIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	var items []interface{}
+	for _, indexer := range i.scope.indexers(i.informer) {
+		found, err := indexer.ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
+
+func (i *scopeIndexer) GetIndexers() cache.Indexers {
+	indexers := i.scope.indexers(i.informer)
+	if len(indexers) == 0 {
+		return cache.Indexers{}
+	}
+	return indexers[0].GetIndexers()
+}
+
+// parseNamespaces splits the comma-separated list of namespaces given to
+// --namespaces, dropping duplicates and empty entries.
+func parseNamespaces(list string) []string {
+	seen := map[string]bool{}
+	var namespaces []string
+	for _, namespace := range strings.Split(list, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// watchNamespaces keeps the namespaces of scope in line with the namespaces
+// whose labels match selector, so that namespaces opt in by being labelled.
+// It returns once the namespaces matching at the start are part of scope.
+func watchNamespaces(ctx context.Context, kubeClient kubernetes.Interface, resync time.Duration, selector string, scope *informerScope) error {
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resync,
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = selector
+		}))
+	informer := factory.Core().V1().Namespaces().Informer()
+	// A namespace whose labels stop matching the selector is reported as
+	// deleted by the watch.
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			scope.addNamespace(obj.(*corev1.Namespace).Name)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if namespace, ok := obj.(*corev1.Namespace); ok {
+				klog.FromContext(ctx).Info("Stopped watching namespace", "namespace", namespace.Name)
+				scope.removeNamespace(namespace.Name)
+			}
+		},
+	}); err != nil {
+		return err
+	}
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return fmt.Errorf("failed to wait for the namespace cache to sync")
+	}
+	return nil
+}
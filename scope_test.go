@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/klog/v2/ktesting"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+func TestScopeListersDispatchByNamespace(t *testing.T) {
+	scope := newInformerScope(k8sfake.NewClientset(), newFooClientset(), 0)
+	scope.addNamespace("team-a")
+	scope.addNamespace("team-b")
+	lister := appslisters.NewDeploymentLister(scope.indexer((*namespaceInformers).deployments))
+
+	for _, namespace := range []string{"team-a", "team-b"} {
+		foo := newFoo("test", int32Ptr(1))
+		foo.Namespace = namespace
+		if err := scope.namespaces[namespace].deployments().GetIndexer().Add(newDeployment(foo, "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, namespace := range []string{"team-a", "team-b"} {
+		d, err := lister.Deployments(namespace).Get("test-deployment")
+		if err != nil {
+			t.Fatalf("expected the Deployment in %s to be found: %v", namespace, err)
+		}
+		if d.Namespace != namespace {
+			t.Errorf("expected the Deployment of %s, got the one of %s", namespace, d.Namespace)
+		}
+	}
+	if _, err := lister.Deployments("team-c").Get("test-deployment"); !errors.IsNotFound(err) {
+		t.Errorf("expected a namespace outside of the scope not to be found, got %v", err)
+	}
+	all, err := lister.List(labels.Everything())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected the Deployments of both namespaces, got %d", len(all))
+	}
+
+	scope.removeNamespace("team-b")
+	if _, err := lister.Deployments("team-b").Get("test-deployment"); !errors.IsNotFound(err) {
+		t.Errorf("expected a removed namespace not to be found, got %v", err)
+	}
+}
+
+func TestSkipsFoosNotSelected(t *testing.T) {
+	f := newFixture(t)
+	f.fooSelector = "shard=1"
+	foo := newFoo("test", int32Ptr(1))
+	foo.Labels = map[string]string{"shard": "2"}
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestFinalizesFooNoLongerSelected(t *testing.T) {
+	f := newFixture(t)
+	f.fooSelector = "shard=1"
+	// The Foo got the finalizer while it was selected.
+	foo := newDeletingFoo(samplecontroller.DeletionPolicyDelete)
+	foo.Labels = map[string]string{"shard": "2"}
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	f.expectDeleteDeploymentAction(d)
+	f.expectUpdateFooAction(withoutFinalizer(foo))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestQueuesFoosOfAddedNamespaceOnceSynced(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	foo := newFoo("test", int32Ptr(1))
+	foo.Namespace = "team-a"
+	scope := newInformerScope(k8sfake.NewClientset(), newFooClientset(foo), 0)
+	cfg, err := defaultConfiguration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewController(ctx, scope.kubeClient, scope.sampleClient, scope, cfg)
+	scope.start(ctx)
+
+	scope.addNamespace("team-a")
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return c.workqueue.Len() == 1, nil
+	})
+	if err != nil {
+		t.Fatal("expected the Foo of the added namespace to be queued")
+	}
+	n := scope.namespaces["team-a"]
+	for name, synced := range map[string]bool{
+		"deployments": n.deployments().HasSynced(),
+		"foos":        n.foos().HasSynced(),
+		"configmaps":  n.configMaps().HasSynced(),
+	} {
+		if !synced {
+			t.Errorf("expected the %s of the namespace to be synced before its Foos are queued", name)
+		}
+	}
+}
+
+func TestWatchNamespaces(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	newNamespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	kubeClient := k8sfake.NewClientset(
+		newNamespace("team-a", map[string]string{"samplecontroller.k8s.io/enabled": "true"}),
+		newNamespace("team-b", map[string]string{"samplecontroller.k8s.io/enabled": "true"}),
+		newNamespace("kube-system", nil),
+	)
+	scope := newInformerScope(kubeClient, newFooClientset(), 0)
+	scope.start(ctx)
+
+	if err := watchNamespaces(ctx, kubeClient, 0, "samplecontroller.k8s.io/enabled=true", scope); err != nil {
+		t.Fatal(err)
+	}
+	if namespaces := scopeNamespaces(scope); !reflect.DeepEqual(namespaces, []string{"team-a", "team-b"}) {
+		t.Errorf("expected the labelled namespaces to be watched, got %v", namespaces)
+	}
+
+	if err := kubeClient.CoreV1().Namespaces().Delete(ctx, "team-b", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return reflect.DeepEqual(scopeNamespaces(scope), []string{"team-a"}), nil
+	})
+	if err != nil {
+		t.Errorf("expected the deleted namespace to be dropped, got %v", scopeNamespaces(scope))
+	}
+}
+
+func TestParseNamespaces(t *testing.T) {
+	if got := parseNamespaces(" team-b,team-a,,team-b "); !reflect.DeepEqual(got, []string{"team-a", "team-b"}) {
+		t.Errorf("unexpected namespaces %v", got)
+	}
+	if got := parseNamespaces(""); len(got) != 0 {
+		t.Errorf("expected no namespaces, got %v", got)
+	}
+}
+
+func scopeNamespaces(scope *informerScope) []string {
+	scope.lock.RLock()
+	defer scope.lock.RUnlock()
+	var namespaces []string
+	for namespace := range scope.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// services returns the kind of the Services controlled by Foos.
+func (c *Controller) services() childKind[corev1.Service, *corev1.Service, *corev1ac.ServiceApplyConfiguration] {
+	return childKind[corev1.Service, *corev1.Service, *corev1ac.ServiceApplyConfiguration]{
+		c:    c,
+		kind: "Service",
+		lister: func(namespace string) childLister[*corev1.Service] {
+			return c.servicesLister.Services(namespace)
+		},
+		client: func(namespace string) childClient[*corev1.Service, *corev1ac.ServiceApplyConfiguration] {
+			return c.kubeclientset.CoreV1().Services(namespace)
+		},
+	}
+}
+
+// syncService makes the Service of foo match spec.service and returns it, or
+// deletes the Service and returns nil when the Foo asks for none. A Service
+// of the same name that the Foo does not control is reported as a conflict.
+func (c *Controller) syncService(ctx context.Context, foo *samplev1alpha1.Foo) (*corev1.Service, error) {
+	if foo.Spec.Service == nil {
+		return nil, c.services().delete(ctx, foo, foo.Name)
+	}
+	return c.services().sync(ctx, foo, foo.Name, newServiceApplyConfiguration(foo))
+}
+
+// newServiceApplyConfiguration returns the Service requested by the
+// spec.service of foo. It is named after the Foo and selects the pods of its
+// Deployment. Only the fields given in spec.service are set, so that the
+// values the API server fills in, like target ports and node ports, are left
+// alone.
+func newServiceApplyConfiguration(foo *samplev1alpha1.Foo) *corev1ac.ServiceApplyConfiguration {
+	spec := corev1ac.ServiceSpec().WithSelector(selectorLabels(foo))
+	if foo.Spec.Service.Type != "" {
+		spec.WithType(foo.Spec.Service.Type)
+	}
+	for _, port := range foo.Spec.Service.Ports {
+		portConfig := corev1ac.ServicePort().WithPort(port.Port)
+		if port.Name != "" {
+			portConfig.WithName(port.Name)
+		}
+		if port.Protocol != "" {
+			portConfig.WithProtocol(port.Protocol)
+		}
+		if port.AppProtocol != nil {
+			portConfig.WithAppProtocol(*port.AppProtocol)
+		}
+		if port.TargetPort.IntValue() != 0 || port.TargetPort.StrVal != "" {
+			portConfig.WithTargetPort(port.TargetPort)
+		}
+		if port.NodePort != 0 {
+			portConfig.WithNodePort(port.NodePort)
+		}
+		spec.WithPorts(portConfig)
+	}
+
+	applyConfig := corev1ac.Service(foo.Name, foo.Namespace).
+		WithAnnotations(foo.Spec.Service.Annotations).
+		WithAnnotations(map[string]string{
+			DesiredStateHashAnnotation: computeHash(foo.Spec.Service),
+		}).
+		WithOwnerReferences(fooOwnerReference(foo)).
+		WithSpec(spec)
+	return applyConfig
+}
+
+// serviceStatus reports service in the status of a Foo.
+func serviceStatus(service *corev1.Service) *samplev1alpha1.FooServiceStatus {
+	if service == nil {
+		return nil
+	}
+	return &samplev1alpha1.FooServiceStatus{
+		Name:      service.Name,
+		ClusterIP: service.Spec.ClusterIP,
+		Ports:     service.Spec.Ports,
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2/ktesting"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/sample-controller/pkg/convert"
+)
+
+// newService returns the Service of foo as the API server stores it, with
+// the cluster IP allocated and the target ports defaulted.
+func newService(t *testing.T, foo *samplecontroller.Foo) *corev1.Service {
+	service := &corev1.Service{}
+	if err := convert.ViaJSON(newServiceApplyConfiguration(foo), service); err != nil {
+		t.Fatal(err)
+	}
+	if service.Spec.Type == "" {
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+	}
+	service.Spec.ClusterIP = "10.0.0.10"
+	for i := range service.Spec.Ports {
+		service.Spec.Ports[i].TargetPort = intstr.FromInt32(service.Spec.Ports[i].Port)
+	}
+	return service
+}
+
+func TestServiceLeavesAllocatedPortsToServer(t *testing.T) {
+	f := newFixture(t)
+	foo := serviceChild.newFoo()
+	foo.Spec.Service.Type = corev1.ServiceTypeNodePort
+	_, ctx := ktesting.NewTestContext(t)
+
+	for _, port := range newServiceApplyConfiguration(foo).Spec.Ports {
+		if port.NodePort != nil || port.TargetPort != nil {
+			t.Fatalf("expected the node and target ports to be left to the API server, got %+v", port)
+		}
+	}
+
+	d := newDeployment(foo, "")
+	// The API server allocated a node port and defaulted the target port.
+	service := newService(t, foo)
+	service.Spec.Ports[0].NodePort = 30080
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.serviceLister = append(f.serviceLister, service)
+	f.kubeobjects = append(f.kubeobjects, d, service)
+
+	// Neither is drift, so the Service is not applied again.
+	status := serviceChild.withStatus(rollingOutStatus(foo), service)
+	if port := status.Service.Ports[0]; port.NodePort != 30080 || port.TargetPort.IntValue() != 80 {
+		t.Fatalf("expected the allocated ports to be reported, got %+v", port)
+	}
+	f.expectApplyFooStatusAction(withStatus(foo, status))
+	f.run(ctx, getRef(foo, t))
+}
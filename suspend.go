@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+const (
+	// ReconcileSuspended is used as part of the Event 'reason' when the
+	// controller stops changing the objects owned by a Foo
+	ReconcileSuspended = "Suspended"
+	// ReconcileResumed is used as part of the Event 'reason' when the
+	// controller starts changing the objects owned by a Foo again
+	ReconcileResumed = "Resumed"
+	// MessageSuspendedBySpec is the message used when a Foo is suspended
+	// through its spec
+	MessageSuspendedBySpec = "Reconciliation suspended by spec.suspend"
+	// MessagePausedByAnnotation is the message used when a Foo is suspended
+	// through the paused annotation
+	MessagePausedByAnnotation = "Reconciliation paused by the %s annotation"
+	// MessageNamespaceSuspended is the message used when a Foo is suspended
+	// because the configuration suspends its namespace
+	MessageNamespaceSuspended = "Reconciliation suspended for namespace %q by the controller configuration"
+	// MessageResumed is the message used for Events when a Foo is no longer
+	// suspended
+	MessageResumed = "Reconciliation resumed"
+)
+
+// Reasons used for the Suspended condition.
+const (
+	// ReasonSuspendedBySpec is used when spec.suspend is true.
+	ReasonSuspendedBySpec = "SuspendedBySpec"
+	// ReasonPausedByAnnotation is used when the Foo carries the paused
+	// annotation.
+	ReasonPausedByAnnotation = "PausedByAnnotation"
+	// ReasonNamespaceSuspended is used when the configuration of the
+	// controller suspends the namespace of the Foo.
+	ReasonNamespaceSuspended = "NamespaceSuspended"
+)
+
+// suspendedCondition returns the Suspended condition describing whether the
+// controller may change the objects owned by foo.
+func suspendedCondition(foo *samplev1alpha1.Foo) metav1.Condition {
+	switch {
+	case foo.Spec.Suspend != nil && *foo.Spec.Suspend:
+		return metav1.Condition{
+			Type:    samplev1alpha1.FooSuspended,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonSuspendedBySpec,
+			Message: MessageSuspendedBySpec,
+		}
+	case foo.Annotations[samplev1alpha1.PausedAnnotation] == "true":
+		return metav1.Condition{
+			Type:    samplev1alpha1.FooSuspended,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonPausedByAnnotation,
+			Message: fmt.Sprintf(MessagePausedByAnnotation, samplev1alpha1.PausedAnnotation),
+		}
+	}
+	return metav1.Condition{
+		Type:   samplev1alpha1.FooSuspended,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonAsExpected,
+	}
+}
+
+// fooSuspendedCondition is suspendedCondition, also suspending the Foos in
+// the namespaces suspended by the configuration of the controller.
+func (c *Controller) fooSuspendedCondition(foo *samplev1alpha1.Foo) metav1.Condition {
+	condition := suspendedCondition(foo)
+	if condition.Status == metav1.ConditionTrue || !c.namespaceSuspended(foo.Namespace) {
+		return condition
+	}
+	return metav1.Condition{
+		Type:    samplev1alpha1.FooSuspended,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonNamespaceSuspended,
+		Message: fmt.Sprintf(MessageNamespaceSuspended, foo.Namespace),
+	}
+}
+
+// namespaceSuspended returns whether the configuration suspends namespace.
+func (c *Controller) namespaceSuspended(namespace string) bool {
+	namespaces := c.suspendedNamespaces.Load()
+	return namespaces != nil && namespaces.Has(namespace)
+}
+
+// recordSuspendTransition emits an Event if condition differs from the
+// Suspended condition last reported for foo.
+func (c *Controller) recordSuspendTransition(foo *samplev1alpha1.Foo, condition metav1.Condition) {
+	suspended := condition.Status == metav1.ConditionTrue
+	if suspended == meta.IsStatusConditionTrue(foo.Status.Conditions, samplev1alpha1.FooSuspended) {
+		return
+	}
+	if suspended {
+		c.recorder.Eventf(foo, nil, corev1.EventTypeNormal, ReconcileSuspended, ActionSync, "%s", condition.Message)
+		return
+	}
+	c.recorder.Eventf(foo, nil, corev1.EventTypeNormal, ReconcileResumed, ActionSync, MessageResumed)
+}
+
+// syncSuspended updates the status of a suspended Foo from its Deployment,
+// Service, PodDisruptionBudget and HorizontalPodAutoscaler as they are,
+// without changing any of them.
+func (c *Controller) syncSuspended(ctx context.Context, foo *samplev1alpha1.Foo, condition metav1.Condition) error {
+	deployment, err := c.deploymentsLister.Deployments(foo.Namespace).Get(foo.Spec.DeploymentName)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err != nil || !metav1.IsControlledBy(deployment, foo) {
+		fooCopy := foo.DeepCopy()
+		c.setFooConditions(fooCopy, condition, metav1.Condition{
+			Type:    samplev1alpha1.FooReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+		return c.writeFooStatus(ctx, foo, fooCopy)
+	}
+	service, err := c.services().owned(foo, foo.Name)
+	if err != nil {
+		return err
+	}
+	pdb, err := c.disruptionBudgets().owned(foo, foo.Name)
+	if err != nil {
+		return err
+	}
+	hpa, err := c.autoscalers().owned(foo, foo.Name)
+	if err != nil {
+		return err
+	}
+	return c.updateFooStatus(ctx, foo, deployment, service, pdb, hpa, condition)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/utils/ptr"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+func TestSuspendedFooLeavesDeploymentAlone(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	foo.Spec.Suspend = ptr.To(true)
+	_, ctx := ktesting.NewTestContext(t)
+
+	// The Deployment was scaled by hand, which is not reverted.
+	d := newDeployment(foo, "")
+	d.Spec.Replicas = int32Ptr(5)
+	d.Status.Replicas = 5
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	c, i, k8sI := f.newController(ctx)
+	recorder := events.NewFakeRecorder(2)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatalf("error syncing foo: %v", err)
+	}
+	if actions := filterInformerActions(f.kubeclient.Actions()); len(actions) != 0 {
+		t.Errorf("expected no changes to the Deployment, got %+v", actions)
+	}
+
+	updated, err := f.client.SamplecontrollerV1alpha1().Foos(foo.Namespace).Get(ctx, foo.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.Replicas != 5 {
+		t.Errorf("expected the status to report 5 replicas, got %d", updated.Status.Replicas)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, samplecontroller.FooSuspended)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ReasonSuspendedBySpec {
+		t.Errorf("expected %s condition to be set, got %+v", samplecontroller.FooSuspended, cond)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, ReconcileSuspended) || !strings.Contains(event, MessageSuspendedBySpec) {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event")
+	}
+}
+
+func TestPausedFooWithoutDeployment(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	foo.Annotations = map[string]string{samplecontroller.PausedAnnotation: "true"}
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	msg := fmt.Sprintf(MessagePausedByAnnotation, samplecontroller.PausedAnnotation)
+	f.expectApplyFooStatusAction(withStatus(foo, samplecontroller.FooStatus{
+		LastSyncTime: &syncTime,
+		Conditions: []metav1.Condition{
+			{Type: samplecontroller.FooSuspended, Status: metav1.ConditionTrue, Reason: ReasonPausedByAnnotation, Message: msg, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooReady, Status: metav1.ConditionFalse, Reason: ReasonPausedByAnnotation, Message: msg, LastTransitionTime: syncTime},
+		},
+	}))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestSuspendedFooGetsNoFinalizer(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	foo.Finalizers = nil
+	foo.Spec.Suspend = ptr.To(true)
+	_, ctx := ktesting.NewTestContext(t)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+
+	// Nothing is created for the Foo while it is suspended, so it is left
+	// without the finalizer until it is resumed.
+	f.expectApplyFooStatusAction(withStatus(foo, samplecontroller.FooStatus{
+		LastSyncTime: &syncTime,
+		Conditions: []metav1.Condition{
+			{Type: samplecontroller.FooSuspended, Status: metav1.ConditionTrue, Reason: ReasonSuspendedBySpec, Message: MessageSuspendedBySpec, LastTransitionTime: syncTime},
+			{Type: samplecontroller.FooReady, Status: metav1.ConditionFalse, Reason: ReasonSuspendedBySpec, Message: MessageSuspendedBySpec, LastTransitionTime: syncTime},
+		},
+	}))
+	f.run(ctx, getRef(foo, t))
+}
+
+func TestResumedFooAppliesDeployment(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.Spec.Replicas = int32Ptr(5)
+
+	// The Foo was suspended when it was last synced.
+	foo.Status.Conditions = []metav1.Condition{
+		{Type: samplecontroller.FooSuspended, Status: metav1.ConditionTrue, Reason: ReasonSuspendedBySpec, Message: MessageSuspendedBySpec, LastTransitionTime: syncTime},
+	}
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	c, i, k8sI := f.newController(ctx)
+	recorder := events.NewFakeRecorder(3)
+	c.recorder = recorder
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	if err := c.syncHandler(ctx, getRef(foo, t)); err != nil {
+		t.Fatalf("error syncing foo: %v", err)
+	}
+	updated, err := f.kubeclient.AppsV1().Deployments(d.Namespace).Get(ctx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *updated.Spec.Replicas != 1 {
+		t.Errorf("expected the replicas to be reverted, got %d", *updated.Spec.Replicas)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, ReconcileResumed) {
+			t.Errorf("unexpected event %q", event)
+		}
+	default:
+		t.Error("expected an event")
+	}
+}
+
+func TestSuspendedCondition(t *testing.T) {
+	tests := map[string]struct {
+		suspend     *bool
+		annotations map[string]string
+		reason      string
+	}{
+		"default":              {reason: ReasonAsExpected},
+		"suspend false":        {suspend: ptr.To(false), reason: ReasonAsExpected},
+		"suspend true":         {suspend: ptr.To(true), reason: ReasonSuspendedBySpec},
+		"paused":               {annotations: map[string]string{samplecontroller.PausedAnnotation: "true"}, reason: ReasonPausedByAnnotation},
+		"paused false":         {annotations: map[string]string{samplecontroller.PausedAnnotation: "false"}, reason: ReasonAsExpected},
+		"suspended and paused": {suspend: ptr.To(true), annotations: map[string]string{samplecontroller.PausedAnnotation: "true"}, reason: ReasonSuspendedBySpec},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			foo := newFoo("test", int32Ptr(1))
+			foo.Spec.Suspend = tc.suspend
+			foo.Annotations = tc.annotations
+			if cond := suspendedCondition(foo); cond.Reason != tc.reason {
+				t.Errorf("expected reason %q, got %+v", tc.reason, cond)
+			}
+		})
+	}
+}
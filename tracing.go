@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Names of the spans of a reconciliation.
+const (
+	SpanReconcile           = "Reconcile Foo"
+	SpanQueueWait           = "Wait in queue"
+	SpanSync                = "Sync Foo"
+	SpanGetFoo              = "Get Foo from cache"
+	SpanResolveDependencies = "Resolve dependencies"
+	SpanGetDeployment       = "Get Deployment from cache"
+)
+
+// tracedQueue is the workqueue of the controller. It remembers since when
+// each queued Foo has been ready to be synced, so that the time the Foo
+// waited for a worker can be traced.
+type tracedQueue struct {
+	workqueue.TypedRateLimitingInterface[cache.ObjectName]
+	rateLimiter workqueue.TypedRateLimiter[cache.ObjectName]
+
+	lock sync.Mutex
+	// readySince holds the earliest time each queued Foo was ready, cleared
+	// when a worker takes the Foo.
+	readySince map[cache.ObjectName]time.Time
+}
+
+func newTracedQueue(rateLimiter workqueue.TypedRateLimiter[cache.ObjectName], config workqueue.TypedRateLimitingQueueConfig[cache.ObjectName]) *tracedQueue {
+	return &tracedQueue{
+		TypedRateLimitingInterface: workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, config),
+		rateLimiter:                rateLimiter,
+		readySince:                 map[cache.ObjectName]time.Time{},
+	}
+}
+
+func (q *tracedQueue) Add(item cache.ObjectName) {
+	q.markReady(item, time.Now())
+	q.TypedRateLimitingInterface.Add(item)
+}
+
+func (q *tracedQueue) AddAfter(item cache.ObjectName, duration time.Duration) {
+	q.markReady(item, time.Now().Add(duration))
+	q.TypedRateLimitingInterface.AddAfter(item, duration)
+}
+
+// AddRateLimited adds item once the rate limiter allows it, like the
+// rate limited queue does.
+func (q *tracedQueue) AddRateLimited(item cache.ObjectName) {
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *tracedQueue) markReady(item cache.ObjectName, ready time.Time) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if since, ok := q.readySince[item]; !ok || ready.Before(since) {
+		q.readySince[item] = ready
+	}
+}
+
+// taken returns since when item, just taken by a worker, was ready. It
+// returns false if that is not known, as for an item added with a delay
+// that ran out after the item had been taken already.
+func (q *tracedQueue) taken(item cache.ObjectName) (time.Time, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	since, ok := q.readySince[item]
+	delete(q.readySince, item)
+	return since, ok
+}
+
+// startReconcileSpan starts the span of the reconciliation of objRef, just
+// taken off the queue. The span starts when objRef was ready to be synced,
+// and the time it waited for a worker is traced as a child span.
+func (c *Controller) startReconcileSpan(ctx context.Context, objRef cache.ObjectName) (context.Context, trace.Span) {
+	attributes := trace.WithAttributes(
+		attribute.String("foo.namespace", objRef.Namespace),
+		attribute.String("foo.name", objRef.Name),
+	)
+	readySince, ok := c.workqueue.taken(objRef)
+	if !ok {
+		return c.tracer.Start(ctx, SpanReconcile, attributes)
+	}
+	ctx, span := c.tracer.Start(ctx, SpanReconcile, attributes, trace.WithTimestamp(readySince))
+	_, wait := c.tracer.Start(ctx, SpanQueueWait, trace.WithTimestamp(readySince))
+	wait.End()
+	return ctx, span
+}
+
+// endSpan ends span, marking it failed if err is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/klog/v2/ktesting"
+
+	samplecontroller "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+)
+
+// processTraced enqueues foo, has a worker sync it and returns the spans
+// recorded by name.
+func (f *fixture) processTraced(ctx context.Context, foo *samplecontroller.Foo) map[string]sdktrace.ReadOnlySpan {
+	c, i, k8sI := f.newController(ctx)
+	recorder := tracetest.NewSpanRecorder()
+	c.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	i.Start(ctx.Done())
+	k8sI.Start(ctx.Done())
+
+	c.workqueue.Add(getRef(foo, f.t))
+	c.processNextWorkItem(ctx)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	return spans
+}
+
+func TestTracesReconcile(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.Status = availableStatus(1)
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	spans := f.processTraced(ctx, foo)
+	parents := map[string]string{
+		SpanReconcile:           "",
+		SpanQueueWait:           SpanReconcile,
+		SpanSync:                SpanReconcile,
+		SpanGetFoo:              SpanSync,
+		SpanResolveDependencies: SpanSync,
+		SpanGetDeployment:       SpanSync,
+	}
+	for name, parent := range parents {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("expected span %q, got %v", name, spans)
+			continue
+		}
+		if parent == "" {
+			if span.Parent().IsValid() {
+				t.Errorf("expected span %q to be a root span", name)
+			}
+			continue
+		}
+		if p, ok := spans[parent]; !ok || span.Parent().SpanID() != p.SpanContext().SpanID() {
+			t.Errorf("expected span %q to be a child of %q", name, parent)
+		}
+	}
+	if reconcile, wait := spans[SpanReconcile], spans[SpanQueueWait]; reconcile != nil && wait != nil {
+		if !reconcile.StartTime().Equal(wait.StartTime()) {
+			t.Errorf("expected the reconciliation to start with the wait in the queue, got %v and %v", reconcile.StartTime(), wait.StartTime())
+		}
+		if reconcile.Status().Code == codes.Error {
+			t.Errorf("expected a successful reconciliation, got %v", reconcile.Status())
+		}
+	}
+}
+
+func TestTracesFailedReconcile(t *testing.T) {
+	f := newFixture(t)
+	foo := newFoo("test", int32Ptr(1))
+	_, ctx := ktesting.NewTestContext(t)
+
+	d := newDeployment(foo, "")
+	d.OwnerReferences = nil
+
+	f.fooLister = append(f.fooLister, foo)
+	f.objects = append(f.objects, foo)
+	f.deploymentLister = append(f.deploymentLister, d)
+	f.kubeobjects = append(f.kubeobjects, d)
+
+	spans := f.processTraced(ctx, foo)
+	for _, name := range []string{SpanReconcile, SpanSync} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("expected span %q, got %v", name, spans)
+			continue
+		}
+		if span.Status().Code != codes.Error {
+			t.Errorf("expected span %q to be failed, got %v", name, span.Status())
+		}
+	}
+}